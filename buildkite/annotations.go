@@ -0,0 +1,69 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Annotation is a build annotation as returned from the REST API
+type Annotation struct {
+	ID        string     `json:"id"`
+	Context   string     `json:"context"`
+	Style     string     `json:"style"`
+	BodyHTML  string     `json:"body_html"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// AnnotationInput is the annotation to create with CreateAnnotation
+type AnnotationInput struct {
+	// Body is the annotation's content as markdown
+	Body string `json:"body"`
+	// Style is one of success, info, warning or error. Left unstyled when empty
+	Style string `json:"style,omitempty"`
+	// Context identifies the annotation within the build. Defaults to "default" when empty
+	Context string `json:"context,omitempty"`
+}
+
+// CreateAnnotation adds an annotation to a build and returns its ID. Buildkite keeps a single annotation per context, so
+// creating an annotation with a context that already exists replaces it rather than adding another.
+func (client *Client) CreateAnnotation(ctx context.Context, pipelineSlug string, number int, input AnnotationInput) (string, error) {
+	switch input.Style {
+	case "", "success", "info", "warning", "error":
+	default:
+		return "", fmt.Errorf("invalid annotation style %q, must be one of success, info, warning or error", input.Style)
+	}
+
+	timeout, err := client.operationTimeout(ctx, "create")
+	if err != nil {
+		return "", err
+	}
+
+	var annotation Annotation
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPost, client.buildPath(pipelineSlug, number)+"/annotations", input, &annotation)
+		return retryContextError(ctx, err)
+	})
+
+	return annotation.ID, err
+}
+
+// ListAnnotations returns every annotation on a build
+func (client *Client) ListAnnotations(ctx context.Context, pipelineSlug string, number int) ([]Annotation, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return paginateREST(ctx, timeout, 0, func(pagination url.Values) ([]Annotation, http.Header, error) {
+		var annotations []Annotation
+		path := fmt.Sprintf("%s/annotations?%s", client.buildPath(pipelineSlug, number), pagination.Encode())
+		header, err := client.doRequest(ctx, http.MethodGet, path, nil, &annotations)
+		return annotations, header, err
+	})
+}
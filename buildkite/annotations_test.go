@@ -0,0 +1,69 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateAnnotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the annotation ID", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/v2/organizations/test-org/pipelines/deploy/builds/3/annotations" {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			var body AnnotationInput
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Context != "deploy-summary" || body.Style != "success" || body.Body != "Deployed **v1.2.3**" {
+				t.Errorf("unexpected annotation: %+v", body)
+			}
+			w.Write([]byte(`{"id": "de0d4ab5-6360-467a-a34b-e5ef5db5320d", "context": "deploy-summary", "style": "success"}`))
+		})
+
+		id, err := client.CreateAnnotation(context.Background(), "deploy", 3, AnnotationInput{
+			Body:    "Deployed **v1.2.3**",
+			Style:   "success",
+			Context: "deploy-summary",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if id != "de0d4ab5-6360-467a-a34b-e5ef5db5320d" {
+			t.Errorf("unexpected annotation ID %s", id)
+		}
+	})
+
+	t.Run("rejects unknown styles", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request to be made")
+		})
+
+		_, err := client.CreateAnnotation(context.Background(), "deploy", 3, AnnotationInput{Body: "hi", Style: "danger"})
+		if err == nil || !strings.Contains(err.Error(), "invalid annotation style") {
+			t.Errorf("expected a style error, got %v", err)
+		}
+	})
+}
+
+func TestListAnnotations(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/organizations/test-org/pipelines/deploy/builds/3/annotations" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"id": "a", "context": "default", "style": "info", "body_html": "<p>hi</p>"}]`))
+	})
+
+	annotations, err := client.ListAnnotations(context.Background(), "deploy", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(annotations) != 1 || annotations[0].BodyHTML != "<p>hi</p>" {
+		t.Errorf("unexpected annotations: %+v", annotations)
+	}
+}
@@ -0,0 +1,111 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// publicIPTimeout bounds the public IP lookup, which only feeds a warning and shouldn't hold up a plan
+const publicIPTimeout = 5 * time.Second
+
+// GetAPIIPAllowlist returns the CIDR ranges allowed to use the organization's API. An empty list means every address is
+// allowed.
+func (client *Client) GetAPIIPAllowlist(ctx context.Context) ([]string, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	var r *getOrganizationResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getOrganization(ctx, client.genqlient, client.organization)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(r.Organization.AllowedApiIpAddresses), nil
+}
+
+// UpdateAPIIPAllowlist replaces the CIDR ranges allowed to use the organization's API and returns the ranges that were
+// saved. An empty list allows every address.
+func (client *Client) UpdateAPIIPAllowlist(ctx context.Context, cidrs []string) ([]string, error) {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q: %w", cidr, err)
+		}
+	}
+
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	var r *setApiIpAddressesResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = setApiIpAddresses(ctx, client.genqlient, client.organizationId, strings.Join(cidrs, " "))
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(r.OrganizationApiIpAllowlistUpdate.Organization.AllowedApiIpAddresses), nil
+}
+
+// publicIP returns the address the provider's requests appear to come from. It's looked up with a plain HTTP client so
+// the API token is never sent to the lookup service.
+func (client *Client) publicIP(ctx context.Context) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(ctx, publicIPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.publicIPUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, &apiError{Method: http.MethodGet, URL: client.publicIPUrl, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("unexpected public IP response %q", strings.TrimSpace(string(body)))
+	}
+	return ip, nil
+}
+
+// allowlistContains reports whether ip is allowed by cidrs. An empty allowlist allows every address, and invalid ranges
+// are ignored.
+func allowlistContains(cidrs []string, ip net.IP) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
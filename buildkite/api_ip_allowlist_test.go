@@ -0,0 +1,167 @@
+package buildkite
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUpdateAPIIPAllowlistValidatesCIDRs(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGraphqlClient(t, func(operation string) string {
+		t.Errorf("unexpected request %s", operation)
+		return ""
+	})
+
+	_, err := client.UpdateAPIIPAllowlist(context.Background(), []string{"10.0.0.0/8", "1.1.1.1"})
+	if err == nil {
+		t.Error("expected an error for a range without a prefix length")
+	}
+}
+
+func TestGetAPIIPAllowlist(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGraphqlClient(t, func(operation string) string {
+		return `{"data": {"organization": {"allowedApiIpAddresses": "10.0.0.0/8 1.1.1.1/32", "id": "T3Jn", "uuid": "123"}}}`
+	})
+
+	cidrs, err := client.GetAPIIPAllowlist(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(cidrs) != 2 || cidrs[0] != "10.0.0.0/8" || cidrs[1] != "1.1.1.1/32" {
+		t.Errorf("unexpected allowlist %v", cidrs)
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("expected the API token not to be sent")
+		}
+		w.Write([]byte("203.0.113.7\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{publicIPUrl: server.URL}
+	ip, err := client.publicIP(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ip.Equal(net.ParseIP("203.0.113.7")) {
+		t.Errorf("unexpected IP %s", ip)
+	}
+}
+
+func TestAllowlistContains(t *testing.T) {
+	t.Parallel()
+
+	ip := net.ParseIP("203.0.113.7")
+	testCases := map[string]struct {
+		cidrs    []string
+		expected bool
+	}{
+		"empty allows everything": {nil, true},
+		"in range":                {[]string{"10.0.0.0/8", "203.0.113.0/24"}, true},
+		"out of range":            {[]string{"10.0.0.0/8"}, false},
+		"invalid range ignored":   {[]string{"nope"}, false},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if actual := allowlistContains(tc.cidrs, ip); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestOrganizationModifyPlanPublicIPLookup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&organizationResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	organization := func(cidrs ...string) tftypes.Value {
+		allowlist := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+		if cidrs != nil {
+			values := make([]tftypes.Value, len(cidrs))
+			for i, cidr := range cidrs {
+				values[i] = tftypes.NewValue(tftypes.String, cidr)
+			}
+			allowlist = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+		}
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, "T3JnYW5pemF0aW9u"),
+			"uuid":                     tftypes.NewValue(tftypes.String, "org-uuid"),
+			"allowed_api_ip_addresses": allowlist,
+			"enforce_2fa":              tftypes.NewValue(tftypes.Bool, false),
+			"sso_session_duration":     tftypes.NewValue(tftypes.Number, nil),
+		})
+	}
+
+	testCases := map[string]struct {
+		state   tftypes.Value
+		plan    tftypes.Value
+		url     func(server string) string
+		lookups int
+	}{
+		"new allowlist":       {state: organization(), plan: organization("10.0.0.0/8"), lookups: 1},
+		"changed allowlist":   {state: organization("10.0.0.0/8"), plan: organization("192.168.0.0/16"), lookups: 1},
+		"unchanged allowlist": {state: organization("10.0.0.0/8"), plan: organization("10.0.0.0/8"), lookups: 0},
+		"no allowlist":        {state: organization(), plan: organization(), lookups: 0},
+		"lookup turned off": {
+			state:   organization(),
+			plan:    organization("10.0.0.0/8"),
+			url:     func(string) string { return "" },
+			lookups: 0,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var lookups int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lookups++
+				w.Write([]byte("10.1.2.3"))
+			}))
+			t.Cleanup(server.Close)
+
+			client := &Client{publicIPUrl: server.URL}
+			if tc.url != nil {
+				client.publicIPUrl = tc.url(server.URL)
+			}
+
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			(&organizationResource{client: client}).ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if lookups != tc.lookups {
+				t.Errorf("expected %d public IP lookups, got %d", tc.lookups, lookups)
+			}
+		})
+	}
+}
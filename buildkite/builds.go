@@ -0,0 +1,384 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Build represents a build as returned from the REST API
+type Build struct {
+	ID          string     `json:"id"`
+	Number      int        `json:"number"`
+	State       string     `json:"state"`
+	Branch      string     `json:"branch"`
+	Commit      string     `json:"commit"`
+	Message     string     `json:"message"`
+	WebURL      string     `json:"web_url"`
+	CreatedAt   *time.Time `json:"created_at"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	Jobs        []BuildJob `json:"jobs"`
+	// Env is decoded loosely because Buildkite returns values as given, which may not be strings
+	Env map[string]interface{} `json:"env"`
+	// MetaData is the build's meta-data, set by its steps with buildkite-agent meta-data set
+	MetaData map[string]string `json:"meta_data"`
+}
+
+// BuildJob is a job within a build as returned from the REST API
+type BuildJob struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Name        string     `json:"name"`
+	State       JobState   `json:"state"`
+	CreatedAt   *time.Time `json:"created_at"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	RunnableAt  *time.Time `json:"runnable_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+}
+
+// TimelineEvent is something that happened to a build or one of its jobs, as returned by GetBuildTimeline
+type TimelineEvent struct {
+	Time time.Time
+	// Event is one of "created", "scheduled", "runnable", "started" or "finished"
+	Event string
+	// JobID and JobName are empty for events of the build itself
+	JobID   string
+	JobName string
+}
+
+// IsFinished reports whether the build has completed and can no longer change state
+func (b Build) IsFinished() bool {
+	return b.FinishedAt != nil
+}
+
+// BuildFilter narrows down the builds returned by ListBuilds. Zero values are not filtered on.
+type BuildFilter struct {
+	// States to include, e.g. "passed" or "failed"
+	State []string
+	// Branches to include
+	Branch []string
+	// Creator is the UUID of the user who created the build
+	Creator     string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// FinishedFrom only includes builds that finished at or after this time
+	FinishedFrom *time.Time
+	// PerPage is how many builds ListBuilds fetches per request, from 1 to 100. Defaults to 100
+	PerPage int
+}
+
+func (f BuildFilter) query() url.Values {
+	query := url.Values{}
+	for _, state := range f.State {
+		query.Add("state[]", state)
+	}
+	for _, branch := range f.Branch {
+		query.Add("branch[]", branch)
+	}
+	if f.Creator != "" {
+		query.Set("creator", f.Creator)
+	}
+	if f.CreatedFrom != nil {
+		query.Set("created_from", f.CreatedFrom.UTC().Format(time.RFC3339))
+	}
+	if f.CreatedTo != nil {
+		query.Set("created_to", f.CreatedTo.UTC().Format(time.RFC3339))
+	}
+	if f.FinishedFrom != nil {
+		query.Set("finished_from", f.FinishedFrom.UTC().Format(time.RFC3339))
+	}
+	return query
+}
+
+func (client *Client) buildPath(pipelineSlug string, number int) string {
+	return fmt.Sprintf("/v2/organizations/%s/pipelines/%s/builds/%d", client.organization, pipelineSlug, number)
+}
+
+// GetBuild fetches a single build of a pipeline by its number
+func (client *Client) GetBuild(ctx context.Context, pipelineSlug string, number int) (Build, error) {
+	var build Build
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return build, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, client.buildPath(pipelineSlug, number), nil, &build)
+		return retryContextError(ctx, err)
+	})
+
+	return build, err
+}
+
+// GetBuildEnv returns the environment variables a build was created with. Values Buildkite has redacted or set to null
+// are returned as empty strings so every variable is present, and non-string values are formatted as JSON.
+func (client *Client) GetBuildEnv(ctx context.Context, pipelineSlug string, number int) (map[string]string, error) {
+	build, err := client.GetBuild(ctx, pipelineSlug, number)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string, len(build.Env))
+	for key, value := range build.Env {
+		switch v := value.(type) {
+		case nil:
+			env[key] = ""
+		case string:
+			env[key] = v
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode env var %s: %w", key, err)
+			}
+			env[key] = string(encoded)
+		}
+	}
+	return env, nil
+}
+
+// GetBuildMetaData returns the value of a build's meta-data key, or ErrNotFound if the build has no value for it
+func (client *Client) GetBuildMetaData(ctx context.Context, pipelineSlug string, number int, key string) (string, error) {
+	build, err := client.GetBuild(ctx, pipelineSlug, number)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := build.MetaData[key]
+	if !ok {
+		return "", fmt.Errorf("meta-data %s on build %s#%d: %w", key, pipelineSlug, number, ErrNotFound)
+	}
+	return value, nil
+}
+
+// GetBuildTimeline returns when a build and each of its jobs were created, scheduled, became runnable, started and
+// finished, ordered by time, e.g. to see how long jobs waited for an agent. The REST API has no timeline of a build, so
+// it's made from the timestamps of the build and its jobs, and only includes the events that have happened so far.
+func (client *Client) GetBuildTimeline(ctx context.Context, pipelineSlug string, number int) ([]TimelineEvent, error) {
+	build, err := client.GetBuild(ctx, pipelineSlug, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []TimelineEvent
+	add := func(at *time.Time, event string, job *BuildJob) {
+		if at == nil {
+			return
+		}
+		e := TimelineEvent{Time: *at, Event: event}
+		if job != nil {
+			e.JobID = job.ID
+			e.JobName = job.Name
+		}
+		events = append(events, e)
+	}
+
+	add(build.CreatedAt, "created", nil)
+	add(build.ScheduledAt, "scheduled", nil)
+	add(build.StartedAt, "started", nil)
+	for i := range build.Jobs {
+		job := &build.Jobs[i]
+		add(job.CreatedAt, "created", job)
+		add(job.ScheduledAt, "scheduled", job)
+		add(job.RunnableAt, "runnable", job)
+		add(job.StartedAt, "started", job)
+		add(job.FinishedAt, "finished", job)
+	}
+	add(build.FinishedAt, "finished", nil)
+
+	// stable, so events at the same time keep the order they happen in
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
+
+// CancelBuild cancels a running or scheduled build and returns its resulting state. Cancelling a build that has already
+// finished is a no-op.
+func (client *Client) CancelBuild(ctx context.Context, pipelineSlug string, number int) (Build, error) {
+	var build Build
+
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return build, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPut, client.buildPath(pipelineSlug, number)+"/cancel", nil, &build)
+		return retryContextError(ctx, err)
+	})
+
+	// Buildkite refuses to cancel builds that are no longer running
+	if isStatusCode(err, http.StatusUnprocessableEntity) {
+		current, getErr := client.GetBuild(ctx, pipelineSlug, number)
+		if getErr == nil && current.IsFinished() {
+			log.Printf("[WARN] Build %s#%d has already finished with state %s, nothing to cancel", pipelineSlug, number, current.State)
+			return current, nil
+		}
+	}
+
+	return build, err
+}
+
+// RebuildBuild creates a new build from an existing one, returning the newly created build
+func (client *Client) RebuildBuild(ctx context.Context, pipelineSlug string, number int) (Build, error) {
+	var build Build
+
+	timeout, err := client.operationTimeout(ctx, "create")
+	if err != nil {
+		return build, err
+	}
+
+	// each rebuild creates a new build, so a rebuild that may have been applied mustn't be sent again
+	ctx = withNotIdempotent(ctx)
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPut, client.buildPath(pipelineSlug, number)+"/rebuild", nil, &build)
+		return retryContextError(ctx, err)
+	})
+
+	if isStatusCode(err, http.StatusNotFound) {
+		return build, fmt.Errorf("cannot rebuild %s#%d: the build no longer exists: %w", pipelineSlug, number, err)
+	}
+
+	return build, err
+}
+
+// ListBuilds returns every build of a pipeline matching the filter, most recently created first
+func (client *Client) ListBuilds(ctx context.Context, pipelineSlug string, filter BuildFilter) ([]Build, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	query := filter.query()
+
+	return paginateREST(ctx, timeout, filter.PerPage, func(pagination url.Values) ([]Build, http.Header, error) {
+		for key, values := range pagination {
+			query[key] = values
+		}
+
+		var builds []Build
+		path := fmt.Sprintf("/v2/organizations/%s/pipelines/%s/builds?%s", client.organization, pipelineSlug, query.Encode())
+		header, err := client.doRequest(ctx, http.MethodGet, path, nil, &builds)
+		return builds, header, err
+	})
+}
+
+// LatestBuild returns the most recently created build of a pipeline matching the filter, or ErrNotFound if none do
+func (client *Client) LatestBuild(ctx context.Context, pipelineSlug string, filter BuildFilter) (Build, error) {
+	builds, err := client.RecentBuilds(ctx, pipelineSlug, filter, 1)
+	if err != nil {
+		return Build{}, err
+	}
+
+	if len(builds) == 0 {
+		return Build{}, fmt.Errorf("no builds of %s match the filter: %w", pipelineSlug, ErrNotFound)
+	}
+	return builds[0], nil
+}
+
+// RecentBuilds returns up to limit of the most recently created builds of a pipeline matching the filter, newest first.
+// Unlike ListBuilds only a single page is requested, so limit must be between 1 and 100.
+func (client *Client) RecentBuilds(ctx context.Context, pipelineSlug string, filter BuildFilter, limit int) ([]Build, error) {
+	if limit < 1 || limit > 100 {
+		return nil, fmt.Errorf("limit must be between 1 and 100, got %d", limit)
+	}
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	// builds are returned newest first, so the first page holds the most recent builds
+	query := filter.query()
+	query.Set("per_page", fmt.Sprint(limit))
+	path := fmt.Sprintf("/v2/organizations/%s/pipelines/%s/builds?%s", client.organization, pipelineSlug, query.Encode())
+
+	var builds []Build
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, path, nil, &builds)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return builds, nil
+}
+
+// UnblockJob unblocks a block step, passing the values for any fields the step asks for. Unblocking a job that has
+// already been unblocked, or whose build has finished, is a no-op.
+func (client *Client) UnblockJob(ctx context.Context, pipelineSlug string, buildNumber int, jobID string, fields map[string]string) error {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{"fields": fields}
+	path := fmt.Sprintf("%s/jobs/%s/unblock", client.buildPath(pipelineSlug, buildNumber), jobID)
+
+	var job BuildJob
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPut, path, payload, &job)
+		return retryContextError(ctx, err)
+	})
+
+	// Buildkite refuses to unblock jobs that aren't blocked anymore, so check whether that's why
+	if isStatusCode(err, http.StatusUnprocessableEntity) {
+		build, getErr := client.GetBuild(ctx, pipelineSlug, buildNumber)
+		if getErr != nil {
+			return err
+		}
+		if build.IsFinished() {
+			log.Printf("[WARN] Build %s#%d has already finished with state %s, nothing to unblock", pipelineSlug, buildNumber, build.State)
+			return nil
+		}
+		for _, j := range build.Jobs {
+			if j.ID == jobID && j.State == JobStateUnblocked {
+				log.Printf("[WARN] Job %s in build %s#%d has already been unblocked", jobID, pipelineSlug, buildNumber)
+				return nil
+			}
+		}
+	}
+
+	return err
+}
+
+// UnblockAllBlockedJobs unblocks every blocked block step of a build in the order they appear, e.g. to approve all the
+// gates of a deploy at once. Jobs that fail to unblock don't stop the others, and are listed in the returned error.
+func (client *Client) UnblockAllBlockedJobs(ctx context.Context, pipelineSlug string, number int) error {
+	build, err := client.GetBuild(ctx, pipelineSlug, number)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, job := range build.Jobs {
+		// block steps are reported as manual jobs by the REST API
+		if job.Type != "manual" || job.State != JobStateBlocked {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := client.UnblockJob(ctx, pipelineSlug, number, job.ID, nil); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to unblock all jobs in build %s#%d: %w", pipelineSlug, number, errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,442 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	genqlient "github.com/Khan/genqlient/graphql"
+)
+
+// newTestClient returns a Client whose REST and GraphQL requests are both answered by handler. GraphQL requests are
+// posted to the server's root and go through the same wrappers as a Client from NewClient.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Client{
+		genqlient:    wrapGenqlientClient(genqlient.NewClient(server.URL, server.Client()), &clientConfig{org: "test-org"}),
+		http:         server.Client(),
+		organization: "test-org",
+		restUrl:      server.URL,
+	}
+}
+
+func TestCancelBuild(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the canceled build", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/v2/organizations/test-org/pipelines/deploy/builds/3/cancel" {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			w.Write([]byte(`{"number": 3, "state": "canceling"}`))
+		})
+
+		build, err := client.CancelBuild(context.Background(), "deploy", 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if build.State != "canceling" {
+			t.Errorf("expected state canceling, got %s", build.State)
+		}
+	})
+
+	t.Run("already finished build is a no-op", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.Write([]byte(`{"number": 3, "state": "passed", "finished_at": "2023-10-01T00:00:00Z"}`))
+		})
+
+		build, err := client.CancelBuild(context.Background(), "deploy", 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if build.State != "passed" {
+			t.Errorf("expected state passed, got %s", build.State)
+		}
+	})
+}
+
+func TestRebuildBuild(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the new build", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/v2/organizations/test-org/pipelines/deploy/builds/3/rebuild" {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			w.Write([]byte(`{"number": 4, "state": "scheduled"}`))
+		})
+
+		build, err := client.RebuildBuild(context.Background(), "deploy", 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if build.Number != 4 {
+			t.Errorf("expected build number 4, got %d", build.Number)
+		}
+	})
+
+	t.Run("errors when the build does not exist", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := client.RebuildBuild(context.Background(), "deploy", 3)
+		if err == nil || !strings.Contains(err.Error(), "no longer exists") {
+			t.Errorf("expected missing build error, got %v", err)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected the error to wrap ErrNotFound, got %v", err)
+		}
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("expected the error to wrap the API error, got %v", err)
+		}
+	})
+
+	t.Run("doesn't resend a rebuild that may have been applied", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		_, err := client.RebuildBuild(context.Background(), "deploy", 3)
+		if !isStatusCode(err, http.StatusServiceUnavailable) {
+			t.Errorf("expected a 503, got %v", err)
+		}
+		if requests != 1 {
+			t.Errorf("expected a single request, got %d", requests)
+		}
+	})
+}
+
+func TestListBuilds(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query["branch[]"]; len(got) != 1 || got[0] != "main" {
+			t.Errorf("expected branch filter main, got %v", got)
+		}
+		if got := query.Get("created_from"); got != "2023-10-01T00:00:00Z" {
+			t.Errorf("expected created_from filter, got %s", got)
+		}
+		if got := query.Get("per_page"); got != "100" {
+			t.Errorf("expected the maximum page size by default, got %s", got)
+		}
+
+		if query.Get("page") == "1" {
+			w.Header().Set("Link", `<https://api.buildkite.com/v2/builds?page=2>; rel="next", <https://api.buildkite.com/v2/builds?page=2>; rel="last"`)
+			w.Write([]byte(`[{"number": 2, "state": "passed"}]`))
+			return
+		}
+		w.Header().Set("Link", `<https://api.buildkite.com/v2/builds?page=1>; rel="first", <https://api.buildkite.com/v2/builds?page=1>; rel="prev"`)
+		w.Write([]byte(`[{"number": 1, "state": "failed"}]`))
+	})
+
+	builds, err := client.ListBuilds(context.Background(), "deploy", BuildFilter{Branch: []string{"main"}, CreatedFrom: &created})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(builds) != 2 || builds[1].Number != 1 {
+		t.Errorf("expected builds from both pages, got %+v", builds)
+	}
+}
+
+func TestListBuildsPageSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uses the requested page size", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("per_page"); got != "10" {
+				t.Errorf("expected per_page=10, got %s", got)
+			}
+			w.Write([]byte(`[]`))
+		})
+
+		if _, err := client.ListBuilds(context.Background(), "deploy", BuildFilter{PerPage: 10}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("rejects page sizes over 100", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request to be made")
+		})
+
+		_, err := client.ListBuilds(context.Background(), "deploy", BuildFilter{PerPage: 101})
+		if err == nil || !strings.Contains(err.Error(), "between 1 and 100") {
+			t.Errorf("expected a page size error, got %v", err)
+		}
+	})
+}
+
+func TestLatestBuild(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the first build", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("per_page"); got != "1" {
+				t.Errorf("expected a single build to be requested, got per_page=%s", got)
+			}
+			w.Write([]byte(`[{"number": 7, "state": "passed"}]`))
+		})
+
+		build, err := client.LatestBuild(context.Background(), "deploy", BuildFilter{State: []string{"passed"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if build.Number != 7 {
+			t.Errorf("expected build 7, got %d", build.Number)
+		}
+	})
+
+	t.Run("returns ErrNotFound when nothing matches", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`[]`))
+		})
+
+		_, err := client.LatestBuild(context.Background(), "deploy", BuildFilter{Branch: []string{"main"}})
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestRecentBuilds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("requests a single page of the limit", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("per_page"); got != "3" {
+				t.Errorf("expected 3 builds to be requested, got per_page=%s", got)
+			}
+			// a next page must not be followed
+			w.Header().Set("Link", `<https://api.buildkite.com/next>; rel="next"`)
+			w.Write([]byte(`[{"number": 9, "state": "running", "branch": "main"}, {"number": 8, "state": "passed", "branch": "main"}]`))
+		})
+
+		builds, err := client.RecentBuilds(context.Background(), "deploy", BuildFilter{}, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(builds) != 2 || builds[0].Number != 9 {
+			t.Errorf("unexpected builds %+v", builds)
+		}
+	})
+
+	t.Run("rejects limits outside a page", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		})
+
+		if _, err := client.RecentBuilds(context.Background(), "deploy", BuildFilter{}, 101); err == nil {
+			t.Error("expected a limit error")
+		}
+	})
+}
+
+func TestUnblockJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends the field values", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/v2/organizations/test-org/pipelines/deploy/builds/3/jobs/abc/unblock" {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			var body struct {
+				Fields map[string]string `json:"fields"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Fields["release"] != "v1.2.3" {
+				t.Errorf("expected release field to be sent, got %v", body.Fields)
+			}
+			w.Write([]byte(`{"id": "abc", "type": "manual", "state": "unblocked"}`))
+		})
+
+		err := client.UnblockJob(context.Background(), "deploy", 3, "abc", map[string]string{"release": "v1.2.3"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("already unblocked job is a no-op", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.Write([]byte(`{"number": 3, "state": "running", "jobs": [{"id": "abc", "type": "manual", "state": "unblocked"}]}`))
+		})
+
+		err := client.UnblockJob(context.Background(), "deploy", 3, "abc", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("other validation errors are returned", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.Write([]byte(`{"number": 3, "state": "blocked", "jobs": [{"id": "abc", "type": "manual", "state": "blocked"}]}`))
+		})
+
+		err := client.UnblockJob(context.Background(), "deploy", 3, "abc", nil)
+		if !isStatusCode(err, http.StatusUnprocessableEntity) {
+			t.Errorf("expected the unblock error, got %v", err)
+		}
+	})
+}
+
+func TestUnblockAllBlockedJobs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unblocks blocked jobs in order", func(t *testing.T) {
+		var unblocked []string
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				unblocked = append(unblocked, r.URL.Path)
+				w.Write([]byte(`{"state": "unblocked"}`))
+				return
+			}
+			w.Write([]byte(`{"number": 3, "state": "blocked", "jobs": [
+				{"id": "build", "type": "script", "state": "passed"},
+				{"id": "staging", "type": "manual", "state": "unblocked"},
+				{"id": "production", "type": "manual", "state": "blocked"},
+				{"id": "cleanup", "type": "manual", "state": "blocked"}
+			]}`))
+		})
+
+		if err := client.UnblockAllBlockedJobs(context.Background(), "deploy", 3); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := []string{
+			"/v2/organizations/test-org/pipelines/deploy/builds/3/jobs/production/unblock",
+			"/v2/organizations/test-org/pipelines/deploy/builds/3/jobs/cleanup/unblock",
+		}
+		if strings.Join(unblocked, ",") != strings.Join(expected, ",") {
+			t.Errorf("expected %v to be unblocked, got %v", expected, unblocked)
+		}
+	})
+
+	t.Run("lists the jobs that failed", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				if strings.Contains(r.URL.Path, "/production/") {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				w.Write([]byte(`{"state": "unblocked"}`))
+				return
+			}
+			w.Write([]byte(`{"number": 3, "state": "blocked", "jobs": [
+				{"id": "production", "type": "manual", "state": "blocked"},
+				{"id": "cleanup", "type": "manual", "state": "blocked"}
+			]}`))
+		})
+
+		err := client.UnblockAllBlockedJobs(context.Background(), "deploy", 3)
+		if err == nil || !strings.Contains(err.Error(), "job production") || strings.Contains(err.Error(), "job cleanup") {
+			t.Errorf("expected only the production job to fail, got %v", err)
+		}
+	})
+}
+
+func TestGetBuildEnv(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number": 3, "state": "passed", "env": {"RELEASE": "v1.2.3", "SECRET": null, "RETRIES": 2, "DEBUG": false}}`))
+	})
+
+	env, err := client.GetBuildEnv(context.Background(), "deploy", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{"RELEASE": "v1.2.3", "SECRET": "", "RETRIES": "2", "DEBUG": "false"}
+	if len(env) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, env)
+	}
+	for key, value := range expected {
+		if got, ok := env[key]; !ok || got != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestGetBuildTimeline(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number": 3, "state": "running",
+			"created_at": "2024-01-01T10:00:00Z", "scheduled_at": "2024-01-01T10:00:00Z", "started_at": "2024-01-01T10:00:20Z",
+			"jobs": [
+				{"id": "test", "name": "Test", "type": "script", "state": "passed", "created_at": "2024-01-01T10:00:01Z",
+					"runnable_at": "2024-01-01T10:00:02Z", "started_at": "2024-01-01T10:00:20Z", "finished_at": "2024-01-01T10:01:00Z"},
+				{"id": "deploy", "name": "Deploy", "type": "script", "state": "scheduled", "created_at": "2024-01-01T10:00:01Z"}
+			]}`))
+	})
+
+	events, err := client.GetBuildTimeline(context.Background(), "deploy", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got []string
+	for _, event := range events {
+		got = append(got, fmt.Sprintf("%s %s %s", event.Time.Format("15:04:05"), event.JobID, event.Event))
+	}
+	expected := []string{
+		"10:00:00  created",
+		"10:00:00  scheduled",
+		"10:00:01 test created",
+		"10:00:01 deploy created",
+		"10:00:02 test runnable",
+		"10:00:20  started",
+		"10:00:20 test started",
+		"10:01:00 test finished",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected timeline:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestGetBuildMetaData(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number": 3, "state": "passed", "meta_data": {"release-version": "v1.2.3"}}`))
+	})
+
+	value, err := client.GetBuildMetaData(context.Background(), "deploy", 3, "release-version")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if value != "v1.2.3" {
+		t.Errorf("expected v1.2.3, got %s", value)
+	}
+
+	_, err = client.GetBuildMetaData(context.Background(), "deploy", 3, "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown key, got %v", err)
+	}
+}
@@ -4,18 +4,53 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"log"
+	"mime"
+	"net"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	genqlient "github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/shurcooL/graphql"
 )
 
+// ErrNotFound is returned when a requested resource doesn't exist. REST API errors with a 404 status code also match it
+// with errors.Is.
+var ErrNotFound = errors.New("not found")
+
+// ErrUnsupported is returned when the Buildkite API being used doesn't support a requested field or setting, for
+// example on older self hosted versions
+var ErrUnsupported = errors.New("not supported by the Buildkite API")
+
+const (
+	defaultAcceptHeader = "application/json"
+	// defaultAgentEndpoint is the agent API, which is authenticated with agent tokens rather than API tokens
+	defaultAgentEndpoint = "https://agent.buildkite.com/v3"
+	defaultDialTimeout   = 10 * time.Second
+	// defaultIdleConnTimeout is kept below the idle timeout of common load balancers, so the provider closes a kept
+	// alive connection before the other end does and doesn't reuse one that's already been dropped
+	defaultIdleConnTimeout = 30 * time.Second
+	// defaultResponseHeaderTimeout is long enough for the slowest GraphQL queries to start responding
+	defaultResponseHeaderTimeout = time.Minute
+	// defaultPublicIPEndpoint returns the caller's public IP address as plain text
+	defaultPublicIPEndpoint = "https://checkip.amazonaws.com"
+	// defaultManagedBy is recorded on resources the provider creates so they can be told apart from ones created in the UI
+	defaultManagedBy = "terraform"
+)
+
 // Client can be used to interact with the Buildkite API
 type Client struct {
 	graphql        *graphql.Client
@@ -24,7 +59,20 @@ type Client struct {
 	organization   string
 	organizationId string
 	restUrl        string
+	agentUrl       string
+	publicIPUrl    string
 	timeouts       timeouts.Value
+	strictDecode   bool
+	requireJSON    bool
+	strictGraphQL  bool
+	decoder        Decoder
+	managedBy      string
+	// clusters caches ClusterFields by name for GetClusterByName
+	clusters sync.Map
+	// graphqlCost records the GraphQL rate limit status for LastGraphQLCost
+	graphqlCost   *graphqlCostRecorder
+	graphqlPacing graphqlPacing
+	tracer        Tracer
 }
 
 type clientConfig struct {
@@ -34,72 +82,350 @@ type clientConfig struct {
 	restURL    string
 	userAgent  string
 	timeouts   timeouts.Value
+	// accept is sent as the default Accept header, allowing a REST API version to be pinned. Defaults to
+	// defaultAcceptHeader
+	accept string
+	// dialTimeout limits how long establishing a TCP connection may take, independent of the overall request timeout.
+	// Defaults to defaultDialTimeout
+	dialTimeout time.Duration
+	// idleConnTimeout is how long an unused kept alive connection is held open before being closed. Defaults to
+	// defaultIdleConnTimeout
+	idleConnTimeout time.Duration
+	// responseHeaderTimeout limits how long the server may take to start responding once a request is sent. It doesn't
+	// cover reading the body, so a large download can take longer as long as it starts promptly. Defaults to
+	// defaultResponseHeaderTimeout
+	responseHeaderTimeout time.Duration
+	// requestTimeout limits a whole request, including reading the response body. Zero means no limit beyond the
+	// operation timeouts
+	requestTimeout time.Duration
+	// maxConcurrentRequests caps how many API requests the provider has in flight at once, across REST and GraphQL.
+	// Zero means unlimited
+	maxConcurrentRequests int
+	// strictDecode makes REST responses containing fields the provider doesn't model fail to decode, to catch API
+	// changes early in tests. Off by default so new API fields don't break the provider
+	strictDecode bool
+	// requireJSON fails successful REST responses that don't have a JSON Content-Type. Otherwise only responses that
+	// are clearly not JSON, like HTML pages, fail
+	requireJSON bool
+	// decoder replaces how REST responses are decoded, e.g. to tolerate numbers returned as strings. Defaults to
+	// encoding/json, honouring strictDecode
+	decoder Decoder
+	// strictGraphQL fails any GraphQL response containing errors, instead of using the data that was resolved when all
+	// of its top level fields are present. It also stops data sources falling back to queries without optional fields
+	strictGraphQL bool
+	// providerDeadline is a ceiling on how long after the client is created any request may still be running, as a
+	// guard against a runaway apply. Unlike the operation timeouts it's absolute. Zero means no deadline
+	providerDeadline time.Duration
+	// managedBy is the value of the managed_by tag added to pipelines the provider manages. Defaults to defaultManagedBy
+	managedBy string
+	// disableManagedByTag stops the provider adding a managed_by tag to pipelines at all
+	disableManagedByTag bool
+	// graphqlPacingThreshold is the fraction of the GraphQL rate limit left below which paginated reads wait between
+	// pages. Defaults to defaultGraphQLPacingThreshold
+	graphqlPacingThreshold float64
+	// graphqlPacingMaxDelay is the longest paginated reads wait between pages. Defaults to defaultGraphQLPacingMaxDelay
+	graphqlPacingMaxDelay time.Duration
+	// disableGraphQLPacing lets paginated reads run at full speed however little of the rate limit is left
+	disableGraphQLPacing bool
+	// publicIPURL returns the public IP address requests come from, to warn about API allowlists that would exclude it.
+	// Defaults to defaultPublicIPEndpoint
+	publicIPURL string
+	// disablePublicIPLookup stops the public IP ever being looked up, e.g. on runners that can't reach the endpoint
+	disablePublicIPLookup bool
+	// tracer is given a span for every REST and GraphQL request. Nil means requests aren't traced
+	tracer Tracer
+	// beforeRequest is called with every REST and GraphQL request just before it's sent, once the provider's headers
+	// have been set, so it can sign or otherwise change the request. An error aborts the request without retrying it
+	beforeRequest func(*http.Request) error
+}
+
+// apiError is returned by makeRequest when the REST API responds with an error status code
+type apiError struct {
+	Method     string
+	URL        string
+	StatusCode int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("Buildkite API request failed: %s %s (returned error %d)", e.Method, e.URL, e.StatusCode)
+}
+
+func (e *apiError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// isStatusCode reports whether err came from a REST API response with the given status code
+func isStatusCode(err error, code int) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
 }
 
 type headerRoundTripper struct {
 	next   http.RoundTripper
 	Header http.Header
+	// beforeRequest, if set, is called once the headers have been added
+	beforeRequest func(*http.Request) error
+}
+
+// newTransport returns the transport all API requests are sent over
+func newTransport(config *clientConfig) *http.Transport {
+	// Dial with an explicit timeout so unreachable endpoints fail fast rather than hanging until the request times out
+	dialTimeout := config.dialTimeout
+	if dialTimeout == 0 {
+		dialTimeout = defaultDialTimeout
+	}
+	idleConnTimeout := config.idleConnTimeout
+	if idleConnTimeout == 0 {
+		idleConnTimeout = defaultIdleConnTimeout
+	}
+	responseHeaderTimeout := config.responseHeaderTimeout
+	if responseHeaderTimeout == 0 {
+		responseHeaderTimeout = defaultResponseHeaderTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	// Long applies can leave connections idle for minutes, and reusing one the server has since closed fails with an
+	// unexpected EOF
+	transport.IdleConnTimeout = idleConnTimeout
+	// A server that never starts responding fails like a dropped connection and is retried, rather than using up the
+	// whole operation timeout
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	transport.ForceAttemptHTTP2 = true
+
+	return transport
+}
+
+// transportTimeoutError is a timeout enforced by the transport itself, such as ResponseHeaderTimeout. The transport's
+// own error matches context.DeadlineExceeded, which would otherwise be mistaken for the caller's deadline and not
+// retried.
+type transportTimeoutError struct {
+	err error
+}
+
+func (e *transportTimeoutError) Error() string   { return e.err.Error() }
+func (e *transportTimeoutError) Timeout() bool   { return true }
+func (e *transportTimeoutError) Temporary() bool { return true }
+
+// transportTimeoutRoundTripper tells the transport's timeouts apart from the request's context expiring
+type transportTimeoutRoundTripper struct {
+	next http.RoundTripper
+}
+
+func newTransportTimeoutRoundTripper(next http.RoundTripper) *transportTimeoutRoundTripper {
+	return &transportTimeoutRoundTripper{next: next}
+}
+
+func (rt *transportTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+		return nil, &transportTimeoutError{err: err}
+	}
+	return resp, err
+}
+
+// wrapGenqlientClient adds the behaviour every GraphQL request made through the client goes through, as configured
+func wrapGenqlientClient(client genqlient.Client, config *clientConfig) genqlient.Client {
+	if !config.strictGraphQL {
+		client = newPartialResultClient(client)
+	}
+	if config.tracer != nil {
+		client = newTracingClient(client, config.tracer, config.org)
+	}
+	return client
 }
 
 // NewClient creates a client to use for interacting with the Buildkite API
 func NewClient(config *clientConfig) (*Client, error) {
-
 	// Setup a HTTP Client that can be used by all REST and graphql API calls,
 	// with suitable headers for authentication and user agent identification
-	rt := http.DefaultTransport
+	var rt http.RoundTripper = newTransportTimeoutRoundTripper(newTransport(config))
 	header := make(http.Header)
 	header.Set("Authorization", "Bearer "+config.apiToken)
 	header.Set("User-Agent", config.userAgent)
-	rt = newHeaderRoundTripper(rt, header)
+	if config.accept != "" {
+		header.Set("Accept", config.accept)
+	} else {
+		header.Set("Accept", defaultAcceptHeader)
+	}
+	headers := newHeaderRoundTripper(rt, header)
+	headers.beforeRequest = config.beforeRequest
+	rt = headers
+	// Every REST and GraphQL request goes through this transport, so throttling here limits them all
+	if config.maxConcurrentRequests > 0 {
+		rt = newLimitRoundTripper(rt, config.maxConcurrentRequests)
+	}
+	// outermost, so time spent waiting for a request slot counts towards the deadline too
+	if config.providerDeadline > 0 {
+		rt = newDeadlineRoundTripper(rt, time.Now().Add(config.providerDeadline))
+	}
 
 	httpClient := &http.Client{
 		Transport: rt,
+		Timeout:   config.requestTimeout,
 	}
 
 	graphqlClient := graphql.NewClient(config.graphqlURL, httpClient)
-	orgId, err := GetOrganizationID(config.org, graphqlClient)
+
+	// This is the first request the provider makes, so it's where a misconfigured endpoint or token shows up
+	ctx := context.Background()
+	timeout, diags := config.timeouts.Read(ctx, DefaultTimeout)
+	if diags.HasError() {
+		return nil, fmt.Errorf("invalid read timeout: %s", diags.Errors()[0].Detail())
+	}
+	var orgId string
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		orgId, err = GetOrganizationID(config.org, graphqlClient)
+		return retryContextError(ctx, err)
+	})
 
 	if err != nil {
-		return nil, err
+		return nil, describeConnectionError(config.graphqlURL, err)
+	}
+
+	managedBy := config.managedBy
+	if managedBy == "" {
+		managedBy = defaultManagedBy
+	}
+	// an empty value means pipelines aren't tagged
+	if config.disableManagedByTag {
+		managedBy = ""
 	}
 
+	publicIPURL := config.publicIPURL
+	if publicIPURL == "" {
+		publicIPURL = defaultPublicIPEndpoint
+	}
+	// an empty URL turns the lookup off
+	if config.disablePublicIPLookup {
+		publicIPURL = ""
+	}
+
+	pacing := graphqlPacing{threshold: config.graphqlPacingThreshold, maxDelay: config.graphqlPacingMaxDelay}
+	if pacing.threshold == 0 {
+		pacing.threshold = defaultGraphQLPacingThreshold
+	}
+	if pacing.maxDelay == 0 {
+		pacing.maxDelay = defaultGraphQLPacingMaxDelay
+	}
+	if config.disableGraphQLPacing {
+		pacing.threshold = 0
+	}
+
+	graphqlCost := newGraphqlCostRecorder(httpClient)
+	genqlientClient := wrapGenqlientClient(genqlient.NewClient(config.graphqlURL, graphqlCost), config)
+
 	return &Client{
 		graphql:        graphqlClient,
-		genqlient:      genqlient.NewClient(config.graphqlURL, httpClient),
+		genqlient:      genqlientClient,
 		http:           httpClient,
 		organization:   config.org,
 		organizationId: orgId,
 		restUrl:        config.restURL,
+		agentUrl:       defaultAgentEndpoint,
+		publicIPUrl:    publicIPURL,
 		timeouts:       config.timeouts,
+		strictDecode:   config.strictDecode,
+		requireJSON:    config.requireJSON,
+		strictGraphQL:  config.strictGraphQL,
+		decoder:        config.decoder,
+		managedBy:      managedBy,
+		graphqlCost:    graphqlCost,
+		graphqlPacing:  pacing,
+		tracer:         config.tracer,
 	}, nil
 }
 
+// RequestContext returns a context that's cancelled once the configured timeout for the given operation (create, read,
+// update or delete) has passed, so requests still in flight are abandoned rather than outliving the operation. Invalid
+// operations or timeouts fall back to DefaultTimeout.
+func (client *Client) RequestContext(parent context.Context, op string) (context.Context, context.CancelFunc) {
+	timeout, err := client.operationTimeout(parent, op)
+	if err != nil {
+		log.Printf("[WARN] Using the default timeout of %s: %s", DefaultTimeout, err)
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// operationTimeout returns the configured timeout for the given operation (create, read, update or delete),
+// falling back to DefaultTimeout when the provider doesn't set one
+func (client *Client) operationTimeout(ctx context.Context, op string) (time.Duration, error) {
+	var timeout time.Duration
+	var diags diag.Diagnostics
+
+	switch op {
+	case "create":
+		timeout, diags = client.timeouts.Create(ctx, DefaultTimeout)
+	case "read":
+		timeout, diags = client.timeouts.Read(ctx, DefaultTimeout)
+	case "update":
+		timeout, diags = client.timeouts.Update(ctx, DefaultTimeout)
+	case "delete":
+		timeout, diags = client.timeouts.Delete(ctx, DefaultTimeout)
+	default:
+		return 0, fmt.Errorf("unknown operation: %s", op)
+	}
+
+	if diags.HasError() {
+		return 0, fmt.Errorf("invalid %s timeout: %s", op, diags.Errors()[0].Detail())
+	}
+	return timeout, nil
+}
+
 func isRetryableError(err error) bool {
-	return isRateLimited(err) || isServerError(err)
+	// the request's own retry policy has already decided not to retry this
+	var policyErr *retryPolicyError
+	if errors.As(err, &policyErr) {
+		return false
+	}
+	if code, ok := errorStatusCode(err); ok {
+		return defaultRetryOn(code)
+	}
+	return isTransientNetworkError(err)
 }
 
-func isRateLimited(err error) bool {
-	// see: https://github.com/Khan/genqlient/blob/main/graphql/client.go#L167
-	r := regexp.MustCompile(`returned error (\d{3}):`)
-	if match := r.FindString(err.Error()); match != "" {
-		code, _ := strconv.Atoi(match)
-		if code == http.StatusTooManyRequests {
-			return true
-		}
+// isTransientNetworkError reports whether a request failed before getting a response for a reason that is likely to
+// go away on its own, such as a dropped connection or a timed out DNS lookup
+func isTransientNetworkError(err error) bool {
+	// the caller's own deadline or cancellation is never worth retrying
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
 	}
-	return false
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
-func isServerError(err error) bool {
-	// see: https://github.com/Khan/genqlient/blob/main/graphql/client.go#L167
-	r := regexp.MustCompile(`returned error (\d{3}):`)
-	if match := r.FindString(err.Error()); match != "" {
-		code, _ := strconv.Atoi(match)
-		if code >= http.StatusBadGateway && code <= http.StatusGatewayTimeout {
-			return true
-		}
+// genqlientStatusPattern matches the status genqlient includes when a GraphQL request fails with an error status, e.g.
+// "returned error 503 Service Unavailable: ..."
+// see: https://github.com/Khan/genqlient/blob/main/graphql/client.go#L167
+var genqlientStatusPattern = regexp.MustCompile(`returned error (\d{3})\b`)
+
+// errorStatusCode returns the HTTP status of the error response a REST or GraphQL request failed with, if it got one
+func errorStatusCode(err error) (int, bool) {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, true
+	}
+	if match := genqlientStatusPattern.FindStringSubmatch(err.Error()); match != nil {
+		code, err := strconv.Atoi(match[1])
+		return code, err == nil
 	}
-	return false
+	return 0, false
 }
 
 func newHeaderRoundTripper(next http.RoundTripper, header http.Header) *headerRoundTripper {
@@ -115,18 +441,184 @@ func newHeaderRoundTripper(next http.RoundTripper, header http.Header) *headerRo
 func (rt *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	if rt.Header != nil {
 		for k, v := range rt.Header {
-			req.Header[k] = v
+			// headers set on the request itself take precedence, e.g. to authenticate with a different token
+			if _, ok := req.Header[k]; !ok {
+				req.Header[k] = v
+			}
+		}
+	}
+	if rt.beforeRequest != nil {
+		if err := rt.beforeRequest(req); err != nil {
+			return nil, fmt.Errorf("request aborted by before request hook: %w", err)
 		}
 	}
 	return rt.next.RoundTrip(req)
 }
 
+// limitRoundTripper is a semaphore allowing at most cap(slots) requests to be sent at once
+type limitRoundTripper struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+func newLimitRoundTripper(next http.RoundTripper, limit int) *limitRoundTripper {
+	return &limitRoundTripper{
+		next:  next,
+		slots: make(chan struct{}, limit),
+	}
+}
+
+func (rt *limitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case rt.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-rt.slots }()
+
+	return rt.next.RoundTrip(req)
+}
+
+// deadlineRoundTripper ends every request at a fixed point in time, regardless of the deadline of its own context
+type deadlineRoundTripper struct {
+	next     http.RoundTripper
+	deadline time.Time
+}
+
+func newDeadlineRoundTripper(next http.RoundTripper, deadline time.Time) *deadlineRoundTripper {
+	return &deadlineRoundTripper{
+		next:     next,
+		deadline: deadline,
+	}
+}
+
+func (rt *deadlineRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !time.Now().Before(rt.deadline) {
+		return nil, rt.deadlineError(req)
+	}
+
+	ctx, cancel := context.WithDeadline(req.Context(), rt.deadline)
+	resp, err := rt.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		// only blame the provider deadline when the request's own context is still live
+		if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+			return nil, rt.deadlineError(req)
+		}
+		return nil, err
+	}
+
+	// the body is still to be read, so the context can only be released once it's closed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (rt *deadlineRoundTripper) deadlineError(req *http.Request) error {
+	tflog.Error(req.Context(), "Aborting Buildkite API request, the provider deadline has been reached", map[string]interface{}{
+		"method":   req.Method,
+		"url":      redactURL(req.URL),
+		"deadline": rt.deadline.Format(time.RFC3339),
+	})
+	return fmt.Errorf("provider deadline of %s reached: %w", rt.deadline.Format(time.RFC3339), context.DeadlineExceeded)
+}
+
+// cancelOnClose releases a request's context once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
 func (client *Client) makeRequest(ctx context.Context, method string, path string, postData interface{}, responseObject interface{}) error {
+	_, err := client.doRequest(ctx, method, path, postData, responseObject)
+	return err
+}
+
+// doRequest is makeRequest but also returns the response headers, for endpoints that return pagination links or other
+// metadata in them. If ctx carries a RetryPolicy, failed requests are retried according to it. Requests that aren't safe
+// to repeat fail with an error callers won't retry, see RetryPolicy.
+func (client *Client) doRequest(ctx context.Context, method string, path string, postData interface{}, responseObject interface{}) (http.Header, error) {
+	policy, ok := retryPolicyFromContext(ctx)
+	if !ok {
+		header, err := client.sendRequest(ctx, method, path, postData, responseObject)
+		if err != nil && !canRetry(ctx, method, err) {
+			return header, &retryPolicyError{err: err}
+		}
+		return header, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		header, err := client.sendRequest(ctx, method, path, postData, responseObject)
+		if err == nil {
+			return header, nil
+		}
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(err) || !canRetry(ctx, method, err) {
+			return header, &retryPolicyError{err: err}
+		}
+		if policy.Reconcile != nil {
+			done, reconcileErr := policy.Reconcile(ctx, err)
+			if reconcileErr != nil {
+				return header, &retryPolicyError{err: reconcileErr}
+			}
+			if done {
+				return header, nil
+			}
+		}
+
+		delay := policy.delay(attempt)
+		logRetry(ctx, method, path, attempt, err, delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return header, &retryPolicyError{err: err}
+		}
+	}
+}
+
+// redactURL returns u as it will be requested, with the values of query parameters and userinfo that may hold
+// credentials replaced, so it can be logged and copied into curl
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+
+	// the query is only re-encoded when something is redacted, so otherwise it's logged exactly as sent
+	query := redacted.Query()
+	var changed bool
+	for key := range query {
+		name := strings.ToLower(key)
+		if strings.Contains(name, "token") || strings.Contains(name, "secret") || strings.Contains(name, "password") {
+			query.Set(key, "REDACTED")
+			changed = true
+		}
+	}
+	if changed {
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.String()
+}
+
+func (client *Client) sendRequest(ctx context.Context, method string, path string, postData interface{}, responseObject interface{}) (header http.Header, err error) {
+	// the path leaves out the query, which may hold credentials
+	ctx, end := startSpan(ctx, client.tracer, "buildkite.rest "+method, client.organization, map[string]string{
+		"http.request.method": method,
+		"url.path":            strings.SplitN(path, "?", 2)[0],
+	})
+	var status int
+	defer func() { end(status, err) }()
+
 	var bodyBytes io.Reader
 	if postData != nil {
 		jsonPayload, err := json.Marshal(postData)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request: %w", err)
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
 		}
 		bodyBytes = bytes.NewBuffer(jsonPayload)
 	}
@@ -135,28 +627,91 @@ func (client *Client) makeRequest(ctx context.Context, method string, path strin
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyBytes)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if key, ok := idempotencyKeyFromContext(ctx); ok {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	tflog.Debug(ctx, "Sending Buildkite API request", map[string]interface{}{
+		"method": method,
+		"url":    redactURL(req.URL),
+	})
 
 	resp, err := client.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
+	defer resp.Body.Close()
+	status = resp.StatusCode
+
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Buildkite API request failed: %s %s (returned error %d)", method, url, resp.StatusCode)
+		return resp.Header, &apiError{Method: method, URL: url, StatusCode: resp.StatusCode}
 	} else if resp.StatusCode == 204 {
-		return nil
+		return resp.Header, nil
 	}
-	defer resp.Body.Close()
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err := checkContentType(resp, client.requireJSON); err != nil {
+		return resp.Header, fmt.Errorf("unexpected response from %s %s: %w", method, redactURL(req.URL), err)
+	}
+
+	decoder := client.decoder
+	if decoder == nil {
+		decoder = jsonDecoder{disallowUnknownFields: client.strictDecode}
+	}
+	if err := decoder.Decode(resp.Body, responseObject); err != nil {
+		return resp.Header, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return resp.Header, nil
+}
+
+// contentTypeSnippetLength is how much of an unexpected response's body is included in the error
+const contentTypeSnippetLength = 200
+
+// checkContentType returns an error including the start of the body when a response isn't JSON. That usually means a
+// proxy or login page answered instead of Buildkite, which would otherwise show up as a confusing decoding error. Only
+// HTML and XML are rejected unless requireJSON is set, as responses that are missing a Content-Type or labelled as text
+// may still be JSON.
+func checkContentType(resp *http.Response, requireJSON bool) error {
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	isJSON := mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	isMarkup := mediaType == "text/html" || strings.HasSuffix(mediaType, "xml")
+	if isJSON || (!isMarkup && !requireJSON) {
+		return nil
 	}
 
-	if err := json.Unmarshal(responseBody, responseObject); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+	if mediaType == "" {
+		mediaType = "no Content-Type"
 	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, contentTypeSnippetLength))
+	return fmt.Errorf("expected JSON but got %s, check proxy and authentication settings: %q", mediaType, strings.Join(strings.Fields(string(snippet)), " "))
+}
+
+// Decoder decodes the body of a REST response into v. An empty body should leave v unchanged rather than fail.
+type Decoder interface {
+	Decode(body io.Reader, v interface{}) error
+}
 
+// jsonDecoder is the default Decoder, using encoding/json
+type jsonDecoder struct {
+	// disallowUnknownFields fails decoding when the body has fields v doesn't have
+	disallowUnknownFields bool
+}
+
+func (d jsonDecoder) Decode(body io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(body)
+	if d.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	// an empty body has nothing to decode, and io.EOF mustn't leak out where it would be retried as a network error
+	if err := decoder.Decode(v); err != nil && err != io.EOF {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,183 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Job represents a job that has been run by an agent
+type Job struct {
+	ID           string
+	UUID         string
+	State        JobState
+	PipelineSlug string
+}
+
+type agentJobsDatasource struct {
+	client *Client
+}
+
+type agentJobsDatasourceModel struct {
+	AgentId types.String    `tfsdk:"agent_id"`
+	Limit   types.Int64     `tfsdk:"limit"`
+	Jobs    []agentJobModel `tfsdk:"jobs"`
+}
+
+// defaultAgentJobsLimit is how many recent jobs are read when limit isn't set
+const defaultAgentJobsLimit = 50
+
+type agentJobModel struct {
+	ID           types.String `tfsdk:"id"`
+	UUID         types.String `tfsdk:"uuid"`
+	State        types.String `tfsdk:"state"`
+	PipelineSlug types.String `tfsdk:"pipeline_slug"`
+}
+
+func newAgentJobsDatasource() datasource.DataSource {
+	return &agentJobsDatasource{}
+}
+
+func (a *agentJobsDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.client = req.ProviderData.(*Client)
+}
+
+func (*agentJobsDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_jobs"
+}
+
+func (*agentJobsDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to list the jobs an agent has recently run. This is useful for finding stuck or
+			misbehaving agents.
+
+			More info in the Buildkite [documentation](https://buildkite.com/docs/agent/v3).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"agent_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GraphQL ID of the agent.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The number of recent jobs to read. Defaults to %d.", defaultAgentJobsLimit),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"jobs": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The jobs most recently assigned to the agent, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the job.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the job.",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The state of the job.",
+						},
+						"pipeline_slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The slug of the pipeline the job belongs to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *agentJobsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state agentJobsDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultAgentJobsLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	jobs, err := a.client.GetAgentJobs(ctx, state.AgentId.ValueString(), limit)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read agent jobs",
+			fmt.Sprintf("Unable to read agent jobs: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Jobs = make([]agentJobModel, len(jobs))
+	for i, job := range jobs {
+		state.Jobs[i] = agentJobModel{
+			ID:           types.StringValue(job.ID),
+			UUID:         types.StringValue(job.UUID),
+			State:        types.StringValue(string(job.State)),
+			PipelineSlug: types.StringValue(job.PipelineSlug),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetAgentJobs returns up to limit of the jobs most recently assigned to the agent with the given GraphQL ID, newest
+// first. Only a single page is requested, so limit must be between 1 and 100.
+func (client *Client) GetAgentJobs(ctx context.Context, agentID string, limit int) ([]Job, error) {
+	if limit < 1 || limit > 100 {
+		return nil, fmt.Errorf("limit must be between 1 and 100, got %d", limit)
+	}
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	var r *getAgentJobsResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getAgentJobs(ctx, client.genqlient, agentID, limit)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	agent, ok := r.Node.(*getAgentJobsNodeAgent)
+	if !ok {
+		return nil, fmt.Errorf("agent %s: %w", agentID, ErrNotFound)
+	}
+
+	var jobs []Job
+	for _, edge := range agent.Jobs.Edges {
+		// agents only ever run command jobs
+		if job, ok := edge.Node.(*getAgentJobsNodeAgentJobsJobConnectionEdgesJobEdgeNodeJobTypeCommand); ok {
+			jobs = append(jobs, Job{
+				ID:           job.Id,
+				UUID:         job.Uuid,
+				State:        ParseJobState(string(job.State)),
+				PipelineSlug: job.Pipeline.Slug,
+			})
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,82 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBuildkiteAgentJobsDatasource(t *testing.T) {
+	t.Run("errors if the agent does not exist", func(t *testing.T) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: `
+						data "buildkite_agent_jobs" "agent" {
+							agent_id = "QWdlbnQtLS0wMDAwMDAwMC0wMDAwLTAwMDAtMDAwMC0wMDAwMDAwMDAwMDA="
+						}
+					`,
+					ExpectError: regexp.MustCompile("Unable to read agent jobs"),
+				},
+			},
+		})
+	})
+}
+
+func TestGetAgentJobs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads a single page of recent jobs", func(t *testing.T) {
+		t.Parallel()
+
+		var requests int
+		client := newTestGraphqlClient(t, func(operation string) string {
+			requests++
+			return `{"data": {"node": {"__typename": "Agent", "jobs": {"edges": [
+				{"node": {"__typename": "JobTypeCommand", "id": "2", "state": "RUNNING", "pipeline": {"slug": "deploy"}}},
+				{"node": {"__typename": "JobTypeCommand", "id": "1", "state": "FINISHED", "pipeline": {"slug": "test"}}}
+			]}}}}`
+		})
+
+		jobs, err := client.GetAgentJobs(context.Background(), "QWdlbnQ=", 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if requests != 1 || len(jobs) != 2 {
+			t.Fatalf("expected 2 jobs from a single request, got %d from %d", len(jobs), requests)
+		}
+		if jobs[1].PipelineSlug != "test" || jobs[1].State != "FINISHED" {
+			t.Errorf("unexpected job: %+v", jobs[1])
+		}
+	})
+
+	t.Run("errors for an unknown agent", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"node": null}}`
+		})
+
+		if _, err := client.GetAgentJobs(context.Background(), "QWdlbnQ=", 10); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("rejects limits over 100", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string {
+			t.Error("expected no request to be made")
+			return ""
+		})
+
+		if _, err := client.GetAgentJobs(context.Background(), "QWdlbnQ=", 101); err == nil {
+			t.Error("expected a limit error")
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// AgentTokenMeta describes an agent token without its secret value, which the API only returns when the token is
+// created
+type AgentTokenMeta struct {
+	ID          string
+	UUID        string
+	Description *string
+	CreatedAt   *time.Time
+	RevokedAt   *time.Time
+}
+
+type agentTokensDatasource struct {
+	client *Client
+}
+
+type agentTokensDatasourceModel struct {
+	Tokens []agentTokensTokenModel `tfsdk:"tokens"`
+}
+
+type agentTokensTokenModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	Description types.String `tfsdk:"description"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	RevokedAt   types.String `tfsdk:"revoked_at"`
+}
+
+func newAgentTokensDatasource() datasource.DataSource {
+	return &agentTokensDatasource{}
+}
+
+func (a *agentTokensDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.client = req.ProviderData.(*Client)
+}
+
+func (*agentTokensDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_tokens"
+}
+
+func (*agentTokensDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to list every agent token in the organization, including revoked tokens, for example
+			to audit which tokens are still outstanding.
+
+			Token values are never returned; Buildkite only reveals them when a token is created.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"tokens": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The agent tokens in the organization.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the agent token.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the agent token.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the agent token.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the agent token was created, as an RFC3339 timestamp.",
+						},
+						"revoked_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the agent token was revoked, as an RFC3339 timestamp. Null if it is still active.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *agentTokensDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state agentTokensDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokens, err := a.client.ListAgentTokens(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read agent tokens",
+			fmt.Sprintf("Unable to read agent tokens: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Tokens = make([]agentTokensTokenModel, len(tokens))
+	for i, token := range tokens {
+		state.Tokens[i] = agentTokensTokenModel{
+			ID:          types.StringValue(token.ID),
+			UUID:        types.StringValue(token.UUID),
+			Description: types.StringPointerValue(token.Description),
+			CreatedAt:   types.StringNull(),
+			RevokedAt:   types.StringNull(),
+		}
+		if token.CreatedAt != nil {
+			state.Tokens[i].CreatedAt = types.StringValue(token.CreatedAt.Format(time.RFC3339))
+		}
+		if token.RevokedAt != nil {
+			state.Tokens[i].RevokedAt = types.StringValue(token.RevokedAt.Format(time.RFC3339))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ListAgentTokens returns every agent token in the organization, including revoked ones. Token values aren't included.
+func (client *Client) ListAgentTokens(ctx context.Context) ([]AgentTokenMeta, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	var r *listAgentTokensResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = listAgentTokens(ctx, client.genqlient, client.organization)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the agent tokens connection has no cursor, so a partial list can't be completed and would hide tokens
+	if r.Organization.AgentTokens.PageInfo.HasNextPage {
+		return nil, fmt.Errorf("organization %s has more agent tokens than can be listed at once", client.organization)
+	}
+
+	tokens := make([]AgentTokenMeta, len(r.Organization.AgentTokens.Edges))
+	for i, edge := range r.Organization.AgentTokens.Edges {
+		tokens[i] = AgentTokenMeta{
+			ID:          edge.Node.Id,
+			UUID:        edge.Node.Uuid,
+			Description: edge.Node.Description,
+			CreatedAt:   edge.Node.CreatedAt,
+			RevokedAt:   edge.Node.RevokedAt,
+		}
+	}
+	return tokens, nil
+}
+
+// RevokeAgentTokensMatching revokes every active agent token for which match returns true, e.g. to clean up tokens
+// with a given description prefix or older than a certain age. A failure to revoke one token doesn't stop the others;
+// the number revoked is returned along with an error listing those that failed.
+func (client *Client) RevokeAgentTokensMatching(ctx context.Context, match func(AgentTokenMeta) bool) (int, error) {
+	tokens, err := client.ListAgentTokens(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	timeout, err := client.operationTimeout(ctx, "delete")
+	if err != nil {
+		return 0, err
+	}
+
+	var revoked int
+	var errs []error
+	for _, token := range tokens {
+		if token.RevokedAt != nil || !match(token) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+			_, err := revokeAgentToken(ctx, client.genqlient, token.ID, "Revoked by Terraform")
+			return retryContextError(ctx, err)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("agent token %s: %w", token.UUID, err))
+			continue
+		}
+		revoked++
+	}
+
+	if len(errs) > 0 {
+		return revoked, fmt.Errorf("unable to revoke all matching agent tokens: %w", errors.Join(errs...))
+	}
+	return revoked, nil
+}
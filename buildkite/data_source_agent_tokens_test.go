@@ -0,0 +1,101 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	genqlient "github.com/Khan/genqlient/graphql"
+)
+
+func TestListAgentTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns active and revoked tokens", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"organization": {"agentTokens": {
+				"pageInfo": {"hasNextPage": false},
+				"edges": [
+					{"node": {"id": "a", "uuid": "1", "description": "default", "createdAt": "2023-10-01T00:00:00Z", "revokedAt": null}},
+					{"node": {"id": "b", "uuid": "2", "description": "old", "createdAt": "2022-10-01T00:00:00Z", "revokedAt": "2023-01-01T00:00:00Z"}}
+				]
+			}}}}`
+		})
+
+		tokens, err := client.ListAgentTokens(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(tokens) != 2 {
+			t.Fatalf("expected 2 tokens, got %+v", tokens)
+		}
+		if tokens[0].RevokedAt != nil || tokens[1].RevokedAt == nil {
+			t.Errorf("expected only the second token to be revoked, got %+v", tokens)
+		}
+	})
+
+	t.Run("errors instead of returning a partial list", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"organization": {"agentTokens": {"pageInfo": {"hasNextPage": true}, "edges": []}}}}`
+		})
+
+		if _, err := client.ListAgentTokens(context.Background()); err == nil {
+			t.Error("expected an error for a truncated list")
+		}
+	})
+}
+
+func TestRevokeAgentTokensMatching(t *testing.T) {
+	t.Parallel()
+
+	var revoked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+		}
+		json.Unmarshal(body, &request)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch request.OperationName {
+		case "listAgentTokens":
+			w.Write([]byte(`{"data": {"organization": {"agentTokens": {
+				"pageInfo": {"hasNextPage": false},
+				"edges": [
+					{"node": {"id": "a", "uuid": "1", "description": "ci-old", "revokedAt": null}},
+					{"node": {"id": "b", "uuid": "2", "description": "ci-broken", "revokedAt": null}},
+					{"node": {"id": "c", "uuid": "3", "description": "ci-revoked", "revokedAt": "2023-01-01T00:00:00Z"}},
+					{"node": {"id": "d", "uuid": "4", "description": "default", "revokedAt": null}}
+				]
+			}}}}`))
+		case "revokeAgentToken":
+			id := request.Variables["id"].(string)
+			if id == "b" {
+				w.Write([]byte(`{"errors": [{"message": "Forbidden"}]}`))
+				return
+			}
+			revoked = append(revoked, id)
+			w.Write([]byte(`{"data": {"agentTokenRevoke": {"agentToken": {"id": "` + id + `", "uuid": ""}}}}`))
+		default:
+			t.Errorf("unexpected operation %s", request.OperationName)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{genqlient: genqlient.NewClient(server.URL, server.Client()), organization: "acme"}
+
+	count, err := client.RevokeAgentTokensMatching(context.Background(), func(token AgentTokenMeta) bool {
+		return token.Description != nil && strings.HasPrefix(*token.Description, "ci-")
+	})
+	if err == nil || !strings.Contains(err.Error(), "agent token 2") {
+		t.Errorf("expected an error for the token that couldn't be revoked, got %v", err)
+	}
+	if count != 1 || len(revoked) != 1 || revoked[0] != "a" {
+		t.Errorf("expected only the first matching token to be revoked, got %d %v", count, revoked)
+	}
+}
@@ -0,0 +1,90 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type buildEnvDatasource struct {
+	client *Client
+}
+
+type buildEnvDatasourceModel struct {
+	PipelineSlug types.String `tfsdk:"pipeline_slug"`
+	Number       types.Int64  `tfsdk:"number"`
+	Env          types.Map    `tfsdk:"env"`
+}
+
+func newBuildEnvDatasource() datasource.DataSource {
+	return &buildEnvDatasource{}
+}
+
+func (b *buildEnvDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	b.client = req.ProviderData.(*Client)
+}
+
+func (*buildEnvDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_build_env"
+}
+
+func (*buildEnvDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to read the environment variables a build was created with, for example to pass a
+			release version from a build to other automation.
+
+			Variables Buildkite has redacted are included with an empty value.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"pipeline_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the pipeline the build belongs to.",
+			},
+			"number": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The number of the build.",
+			},
+			"env": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The build's environment variables.",
+			},
+		},
+	}
+}
+
+func (b *buildEnvDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state buildEnvDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env, err := b.client.GetBuildEnv(ctx, state.PipelineSlug.ValueString(), int(state.Number.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read build env",
+			fmt.Sprintf("Unable to read build env: %s", err.Error()),
+		)
+		return
+	}
+
+	value, diags := types.MapValueFrom(ctx, types.StringType, env)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Env = value
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,125 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type buildTimelineDatasource struct {
+	client *Client
+}
+
+type buildTimelineDatasourceModel struct {
+	PipelineSlug types.String              `tfsdk:"pipeline_slug"`
+	Number       types.Int64               `tfsdk:"number"`
+	Events       []buildTimelineEventModel `tfsdk:"events"`
+}
+
+type buildTimelineEventModel struct {
+	Time    types.String `tfsdk:"time"`
+	Event   types.String `tfsdk:"event"`
+	JobID   types.String `tfsdk:"job_id"`
+	JobName types.String `tfsdk:"job_name"`
+}
+
+func newBuildTimelineDatasource() datasource.DataSource {
+	return &buildTimelineDatasource{}
+}
+
+func (b *buildTimelineDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	b.client = req.ProviderData.(*Client)
+}
+
+func (*buildTimelineDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_build_timeline"
+}
+
+func (*buildTimelineDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to read when a build and each of its jobs were created, scheduled, became runnable,
+			started and finished, for example to report how long jobs waited for an agent.
+
+			Only the events that have happened when the data source is read are included.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"pipeline_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the pipeline the build belongs to.",
+			},
+			"number": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The number of the build.",
+			},
+			"events": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The events of the build and its jobs, ordered by time.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"time": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the event happened, in RFC 3339 format.",
+						},
+						"event": schema.StringAttribute{
+							Computed: true,
+							MarkdownDescription: "What happened, one of `created`, `scheduled`, `runnable`, `started` or " +
+								"`finished`. Only jobs become `runnable`.",
+						},
+						"job_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the job the event happened to, or null for events of the build itself.",
+						},
+						"job_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the job the event happened to, or null for events of the build itself.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *buildTimelineDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state buildTimelineDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	events, err := b.client.GetBuildTimeline(ctx, state.PipelineSlug.ValueString(), int(state.Number.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read build timeline",
+			fmt.Sprintf("Unable to read build timeline: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Events = make([]buildTimelineEventModel, len(events))
+	for i, event := range events {
+		state.Events[i] = buildTimelineEventModel{
+			Time:    types.StringValue(event.Time.UTC().Format(time.RFC3339)),
+			Event:   types.StringValue(event.Event),
+			JobID:   types.StringNull(),
+			JobName: types.StringNull(),
+		}
+		if event.JobID != "" {
+			state.Events[i].JobID = types.StringValue(event.JobID)
+			state.Events[i].JobName = types.StringValue(event.JobName)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
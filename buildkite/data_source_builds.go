@@ -0,0 +1,184 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type buildsDatasource struct {
+	client *Client
+}
+
+type buildsDatasourceModel struct {
+	PipelineSlug types.String   `tfsdk:"pipeline_slug"`
+	State        []types.String `tfsdk:"state"`
+	Branch       []types.String `tfsdk:"branch"`
+	Creator      types.String   `tfsdk:"creator"`
+	CreatedFrom  types.String   `tfsdk:"created_from"`
+	CreatedTo    types.String   `tfsdk:"created_to"`
+	Builds       []buildModel   `tfsdk:"builds"`
+}
+
+type buildModel struct {
+	Number     types.Int64  `tfsdk:"number"`
+	State      types.String `tfsdk:"state"`
+	Branch     types.String `tfsdk:"branch"`
+	Commit     types.String `tfsdk:"commit"`
+	WebURL     types.String `tfsdk:"web_url"`
+	FinishedAt types.String `tfsdk:"finished_at"`
+}
+
+func newBuildsDatasource() datasource.DataSource {
+	return &buildsDatasource{}
+}
+
+func (b *buildsDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	b.client = req.ProviderData.(*Client)
+}
+
+func (*buildsDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_builds"
+}
+
+func (*buildsDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to list the builds of a pipeline, optionally filtered by state, branch, creator and
+			creation time. This is useful for reporting, or for finding the latest passing build of a branch.
+
+			More info in the Buildkite [documentation](https://buildkite.com/docs/apis/rest-api/builds).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"pipeline_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the pipeline to list builds for.",
+			},
+			"state": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only include builds in one of these states, e.g. `passed` or `failed`.",
+			},
+			"branch": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only include builds of one of these branches.",
+			},
+			"creator": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only include builds created by the user with this UUID.",
+			},
+			"created_from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only include builds created at or after this RFC3339 timestamp.",
+			},
+			"created_to": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only include builds created before this RFC3339 timestamp.",
+			},
+			"builds": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching builds, most recently created first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"number": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The build number.",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The state of the build.",
+						},
+						"branch": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The branch the build ran on.",
+						},
+						"commit": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The commit the build ran on.",
+						},
+						"web_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The URL of the build in the Buildkite UI.",
+						},
+						"finished_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The time the build finished, if it has.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *buildsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state buildsDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := BuildFilter{Creator: state.Creator.ValueString()}
+	for _, s := range state.State {
+		filter.State = append(filter.State, s.ValueString())
+	}
+	for _, branch := range state.Branch {
+		filter.Branch = append(filter.Branch, branch.ValueString())
+	}
+	for attribute, value := range map[string]types.String{"created_from": state.CreatedFrom, "created_to": state.CreatedTo} {
+		if value.IsNull() {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid timestamp", fmt.Sprintf("%s must be an RFC3339 timestamp: %s", attribute, err.Error()))
+			continue
+		}
+		if attribute == "created_from" {
+			filter.CreatedFrom = &t
+		} else {
+			filter.CreatedTo = &t
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	builds, err := b.client.ListBuilds(ctx, state.PipelineSlug.ValueString(), filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read builds",
+			fmt.Sprintf("Unable to read builds: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Builds = make([]buildModel, len(builds))
+	for i, build := range builds {
+		state.Builds[i] = buildModel{
+			Number:     types.Int64Value(int64(build.Number)),
+			State:      types.StringValue(build.State),
+			Branch:     types.StringValue(build.Branch),
+			Commit:     types.StringValue(build.Commit),
+			WebURL:     types.StringValue(build.WebURL),
+			FinishedAt: types.StringNull(),
+		}
+		if build.FinishedAt != nil {
+			state.Builds[i].FinishedAt = types.StringValue(build.FinishedAt.Format(time.RFC3339))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,28 @@
+package buildkite
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBuildkiteBuildsDatasource(t *testing.T) {
+	t.Run("errors on an invalid created_from", func(t *testing.T) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: `
+						data "buildkite_builds" "builds" {
+							pipeline_slug = "does-not-matter"
+							created_from = "yesterday"
+						}
+					`,
+					ExpectError: regexp.MustCompile("must be an RFC3339 timestamp"),
+				},
+			},
+		})
+	})
+}
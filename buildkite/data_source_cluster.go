@@ -2,12 +2,12 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 type clusterDatasource struct {
@@ -38,61 +38,25 @@ func (c *clusterDatasource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	timeout, diags := c.client.timeouts.Read(ctx, DefaultTimeout)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	cluster, err := c.client.GetClusterByName(ctx, state.Name.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Unable to find Cluster", fmt.Sprintf("Could not find cluster with name \"%s\"", state.Name.ValueString()))
 		return
 	}
-
-	var matchFound bool
-	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
-		var cursor *string
-		for {
-			r, err := getClusterByName(ctx, c.client.genqlient, c.client.organization, cursor)
-			if err != nil {
-				if isRetryableError(err) {
-					return retry.RetryableError(err)
-				}
-				resp.Diagnostics.AddError(
-					"Unable to read Cluster",
-					fmt.Sprintf("Unable to read Cluster: %s", err.Error()),
-				)
-				return retry.NonRetryableError(err)
-			}
-
-			// loop over this page of results to try find the matching cluster
-			for _, cluster := range r.Organization.Clusters.Edges {
-				if cluster.Node.Name == state.Name.ValueString() {
-					matchFound = true
-					state.Color = types.StringPointerValue(cluster.Node.Color)
-					state.Description = types.StringPointerValue(cluster.Node.Description)
-					state.Emoji = types.StringPointerValue(cluster.Node.Emoji)
-					state.ID = types.StringValue(cluster.Node.Id)
-					state.Name = types.StringValue(cluster.Node.Name)
-					state.UUID = types.StringValue(cluster.Node.Uuid)
-					break
-				}
-			}
-
-			// end here if we found a match or there are no more pages to search
-			if matchFound || !r.Organization.Clusters.PageInfo.HasNextPage {
-				break
-			}
-			cursor = &r.Organization.Clusters.PageInfo.EndCursor
-		}
-		return nil
-	})
-
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to find Cluster", err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to read Cluster",
+			fmt.Sprintf("Unable to read Cluster: %s", err.Error()),
+		)
 		return
 	}
 
-	// if there is no match found by here then the cluster doesn't exist
-	if !matchFound {
-		resp.Diagnostics.AddError("Unable to find Cluster", fmt.Sprintf("Could not find cluster with name \"%s\"", state.Name.ValueString()))
-		return
-	}
+	state.Color = types.StringPointerValue(cluster.Color)
+	state.Description = types.StringPointerValue(cluster.Description)
+	state.Emoji = types.StringPointerValue(cluster.Emoji)
+	state.ID = types.StringValue(cluster.Id)
+	state.Name = types.StringValue(cluster.Name)
+	state.UUID = types.StringValue(cluster.Uuid)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -0,0 +1,166 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type clustersDatasource struct {
+	client *Client
+}
+
+type clustersDatasourceModel struct {
+	Clusters []clustersClusterModel `tfsdk:"clusters"`
+}
+
+type clustersClusterModel struct {
+	ID              types.String `tfsdk:"id"`
+	UUID            types.String `tfsdk:"uuid"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Emoji           types.String `tfsdk:"emoji"`
+	Color           types.String `tfsdk:"color"`
+	DefaultQueueID  types.String `tfsdk:"default_queue_id"`
+	DefaultQueueKey types.String `tfsdk:"default_queue_key"`
+}
+
+func newClustersDatasource() datasource.DataSource {
+	return &clustersDatasource{}
+}
+
+func (c *clustersDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c.client = req.ProviderData.(*Client)
+}
+
+func (*clustersDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clusters"
+}
+
+func (*clustersDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to list every cluster in the organization.
+
+			More info in the Buildkite [documentation](https://buildkite.com/docs/clusters/overview).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"clusters": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The clusters in the organization, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the cluster.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the cluster.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the cluster.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the cluster.",
+						},
+						"emoji": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The emoji of the cluster.",
+						},
+						"color": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The color of the cluster.",
+						},
+						"default_queue_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the cluster's default queue, if it has one.",
+						},
+						"default_queue_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The key of the cluster's default queue, if it has one.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *clustersDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state clustersDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := c.client.ListClusters(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read clusters",
+			fmt.Sprintf("Unable to read clusters: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Clusters = make([]clustersClusterModel, len(clusters))
+	for i, cluster := range clusters {
+		state.Clusters[i] = clustersClusterModel{
+			ID:              types.StringValue(cluster.Id),
+			UUID:            types.StringValue(cluster.Uuid),
+			Name:            types.StringValue(cluster.Name),
+			Description:     types.StringPointerValue(cluster.Description),
+			Emoji:           types.StringPointerValue(cluster.Emoji),
+			Color:           types.StringPointerValue(cluster.Color),
+			DefaultQueueID:  types.StringNull(),
+			DefaultQueueKey: types.StringNull(),
+		}
+		if cluster.DefaultQueue != nil {
+			state.Clusters[i].DefaultQueueID = types.StringValue(cluster.DefaultQueue.Id)
+			state.Clusters[i].DefaultQueueKey = types.StringValue(cluster.DefaultQueue.Key)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ListClusters returns every cluster in the organization, ordered by name. The clusters are cached for
+// GetClusterByName.
+func (client *Client) ListClusters(ctx context.Context) ([]ClusterFields, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]ClusterFields, pageInfo, error) {
+		r, err := listClusters(ctx, client.genqlient, client.organization, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var clusters []ClusterFields
+		for _, edge := range r.Organization.Clusters.Edges {
+			clusters = append(clusters, edge.Node.ClusterFields)
+		}
+		return clusters, &r.Organization.Clusters.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		client.clusters.Store(cluster.Name, cluster)
+	}
+	return clusters, nil
+}
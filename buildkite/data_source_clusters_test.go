@@ -0,0 +1,75 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBuildkiteClustersDatasource(t *testing.T) {
+	t.Run("lists clusters including a new cluster", func(t *testing.T) {
+		name := acctest.RandString(12)
+
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+						resource "buildkite_cluster" "cluster" {
+							name = "%s"
+							description = "Listed by buildkite_clusters"
+						}
+
+						data "buildkite_clusters" "all" {
+							depends_on = [buildkite_cluster.cluster]
+						}
+					`, name),
+					Check: resource.TestCheckTypeSetElemNestedAttrs("data.buildkite_clusters.all", "clusters.*", map[string]string{
+						"name":        name,
+						"description": "Listed by buildkite_clusters",
+					}),
+				},
+			},
+		})
+	})
+}
+
+func TestListClusters(t *testing.T) {
+	t.Parallel()
+
+	var page int
+	client := newTestGraphqlClient(t, func(operation string) string {
+		page++
+		if page == 1 {
+			return `{"data": {"organization": {"clusters": {
+				"pageInfo": {"endCursor": "first", "hasNextPage": true},
+				"edges": [{"node": {"id": "Q2x1c3Rlci0tLWE=", "uuid": "a", "name": "default",
+					"defaultQueue": {"id": "UXVldWUtLS1h", "key": "default"}}}]
+			}}}}`
+		}
+		return `{"data": {"organization": {"clusters": {
+			"pageInfo": {"endCursor": "second", "hasNextPage": false},
+			"edges": [{"node": {"id": "Q2x1c3Rlci0tLWI=", "uuid": "b", "name": "deploy"}}]
+		}}}}`
+	})
+
+	clusters, err := client.ListClusters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(clusters) != 2 {
+		t.Fatalf("expected clusters from both pages, got %d", len(clusters))
+	}
+	if clusters[0].DefaultQueue == nil || clusters[0].DefaultQueue.Key != "default" || clusters[1].DefaultQueue != nil {
+		t.Errorf("unexpected clusters: %+v", clusters)
+	}
+
+	// listing caches the clusters for GetClusterByName
+	if _, err := client.GetClusterByName(context.Background(), "deploy"); err != nil || page != 2 {
+		t.Errorf("expected a cached cluster after 2 requests, got %v after %d", err, page)
+	}
+}
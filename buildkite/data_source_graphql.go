@@ -0,0 +1,132 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+type graphqlDatasource struct {
+	client *Client
+}
+
+type graphqlDatasourceModel struct {
+	Query     types.String `tfsdk:"query"`
+	Variables types.String `tfsdk:"variables"`
+	Result    types.String `tfsdk:"result"`
+}
+
+func newGraphqlDatasource() datasource.DataSource {
+	return &graphqlDatasource{}
+}
+
+func (g *graphqlDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	g.client = req.ProviderData.(*Client)
+}
+
+func (*graphqlDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graphql"
+}
+
+func (*graphqlDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to run a GraphQL query against the Buildkite API and read fields the provider doesn't
+			model yet.
+
+			Only queries are allowed; documents containing mutations or subscriptions are rejected.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GraphQL query to run.",
+			},
+			"variables": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A JSON encoded object of variables to pass to the query.",
+			},
+			"result": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The JSON encoded `data` returned by the query.",
+			},
+		},
+	}
+}
+
+func (g *graphqlDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state graphqlDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var variables map[string]interface{}
+	if !state.Variables.IsNull() {
+		if err := json.Unmarshal([]byte(state.Variables.ValueString()), &variables); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid GraphQL variables",
+				fmt.Sprintf("Unable to decode variables as a JSON object: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	var result json.RawMessage
+	err := g.client.RawGraphQL(ctx, state.Query.ValueString(), variables, &result)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to run GraphQL query",
+			fmt.Sprintf("Unable to run GraphQL query: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Result = types.StringValue(string(result))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// RawGraphQL runs an arbitrary GraphQL query and decodes its data into the given value. Documents containing anything
+// other than query operations are rejected.
+func (client *Client) RawGraphQL(ctx context.Context, query string, variables map[string]interface{}, into interface{}) error {
+	document, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return fmt.Errorf("unable to parse query: %w", err)
+	}
+	if len(document.Operations) == 0 {
+		return fmt.Errorf("document contains no operations")
+	}
+	for _, operation := range document.Operations {
+		if operation.Operation != ast.Query {
+			return fmt.Errorf("only query operations are allowed, got %s", operation.Operation)
+		}
+	}
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.genqlient.MakeRequest(ctx, &graphql.Request{
+			Query:     query,
+			Variables: variables,
+			OpName:    document.Operations[0].Name,
+		}, &graphql.Response{Data: into})
+		return retryContextError(ctx, err)
+	})
+}
@@ -0,0 +1,43 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRawGraphQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decodes the query data", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			if operation != "getViewer" {
+				t.Errorf("expected the operation name to be sent, got %q", operation)
+			}
+			return `{"data": {"viewer": {"user": {"name": "Buildkite"}}}}`
+		})
+
+		var result json.RawMessage
+		err := client.RawGraphQL(context.Background(), "query getViewer { viewer { user { name } } }", nil, &result)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(result) != `{"viewer": {"user": {"name": "Buildkite"}}}` {
+			t.Errorf("unexpected result: %s", result)
+		}
+	})
+
+	t.Run("rejects mutations", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			t.Error("expected no request to be made")
+			return ""
+		})
+
+		query := "query a { viewer { id } }\nmutation b { pipelineDelete(input: {id: \"x\"}) { clientMutationId } }"
+		err := client.RawGraphQL(context.Background(), query, nil, &json.RawMessage{})
+		if err == nil || !strings.Contains(err.Error(), "only query operations are allowed") {
+			t.Errorf("expected the mutation to be rejected, got %v", err)
+		}
+	})
+}
@@ -44,7 +44,18 @@ func (o *organizationDatasource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	response, err := getOrganization(ctx, o.client.genqlient, o.client.organization)
+	var response *getOrganizationResponse
+	var required *getOrganizationRequiredFieldsResponse
+	// the IP allowlist is only available on some plans
+	fellBack, err := o.client.withOptionalFields(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = getOrganization(ctx, o.client.genqlient, o.client.organization)
+		return err
+	}, func(ctx context.Context) error {
+		var err error
+		required, err = getOrganizationRequiredFields(ctx, o.client.genqlient, o.client.organization)
+		return err
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read organization settings",
@@ -53,6 +64,14 @@ func (o *organizationDatasource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	if fellBack {
+		state.ID = types.StringValue(required.Organization.Id)
+		state.UUID = types.StringValue(required.Organization.Uuid)
+		state.AllowedApiIpAddresses = types.ListNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
 	state.ID = types.StringValue(response.Organization.Id)
 	state.UUID = types.StringValue(response.Organization.Uuid)
 	ips, diag := types.ListValueFrom(ctx, types.StringType, strings.Split(response.Organization.AllowedApiIpAddresses, " "))
@@ -81,7 +100,7 @@ func (*organizationDatasource) Schema(ctx context.Context, req datasource.Schema
 			"allowed_api_ip_addresses": schema.ListAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of IP addresses in CIDR format that are allowed to access the Buildkite API for this organization.",
+				MarkdownDescription: "List of IP addresses in CIDR format that are allowed to access the Buildkite API for this organization. Null if the organization's plan doesn't include the IP allowlist.",
 			},
 		},
 	}
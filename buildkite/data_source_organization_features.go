@@ -0,0 +1,104 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// OrganizationFeatures is which optional features are enabled for the organization. Clusters, packages and test
+// analytics are available to every organization, so the API has no flags for them.
+type OrganizationFeatures struct {
+	// TeamsEnabled is false until the organization's first team is created, and teams are needed for team resources
+	TeamsEnabled bool
+	SSOEnabled   bool
+}
+
+type organizationFeaturesDatasource struct {
+	client *Client
+}
+
+type organizationFeaturesDatasourceModel struct {
+	TeamsEnabled types.Bool `tfsdk:"teams_enabled"`
+	SSOEnabled   types.Bool `tfsdk:"sso_enabled"`
+}
+
+func newOrganizationFeaturesDatasource() datasource.DataSource {
+	return &organizationFeaturesDatasource{}
+}
+
+func (o *organizationFeaturesDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	o.client = req.ProviderData.(*Client)
+}
+
+func (*organizationFeaturesDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_features"
+}
+
+func (*organizationFeaturesDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to check which optional features are enabled for the organization, for example to
+			only create teams once teams are enabled.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"teams_enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether teams are enabled for the organization.",
+			},
+			"sso_enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether members sign in through single sign-on.",
+			},
+		},
+	}
+}
+
+func (o *organizationFeaturesDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	features, err := o.client.GetOrganizationFeatures(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read organization features",
+			fmt.Sprintf("Unable to read organization features: %s", err.Error()),
+		)
+		return
+	}
+
+	state := organizationFeaturesDatasourceModel{
+		TeamsEnabled: types.BoolValue(features.TeamsEnabled),
+		SSOEnabled:   types.BoolValue(features.SSOEnabled),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetOrganizationFeatures returns which optional features are enabled for the organization
+func (client *Client) GetOrganizationFeatures(ctx context.Context) (OrganizationFeatures, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return OrganizationFeatures{}, err
+	}
+
+	var r *getOrganizationFeaturesResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getOrganizationFeatures(ctx, client.genqlient, client.organization)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return OrganizationFeatures{}, err
+	}
+
+	return OrganizationFeatures{
+		TeamsEnabled: r.Organization.IsTeamsEnabled,
+		SSOEnabled:   r.Organization.Sso.IsEnabled,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package buildkite
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetOrganizationFeatures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads the feature flags", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"organization": {"isTeamsEnabled": true, "sso": {"isEnabled": true}}}}`
+		})
+
+		features, err := client.GetOrganizationFeatures(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !features.TeamsEnabled || !features.SSOEnabled {
+			t.Errorf("expected teams and SSO to be enabled, got %+v", features)
+		}
+	})
+
+	t.Run("treats missing SSO settings as disabled", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"organization": {"isTeamsEnabled": false, "sso": null}}}`
+		})
+
+		features, err := client.GetOrganizationFeatures(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if features.SSOEnabled {
+			t.Errorf("expected SSO to be disabled, got %+v", features)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Usage is an organization's billable usage over a month
+type Usage struct {
+	// From and To are the first and last days of the month, formatted as 2006-01-02
+	From string
+	To   string
+	// JobMinutes is the job time of every pipeline, summed and rounded down to whole minutes as it's billed
+	JobMinutes     int
+	TestExecutions int
+	// Seats is the number of members the organization has now. Buildkite doesn't report past membership, so it isn't
+	// limited to the month.
+	Seats int
+	// Days has the usage of each day of the month that had any, in date order
+	Days []DailyUsage
+}
+
+// DailyUsage is an organization's usage on a single day, summed across its pipelines and test suites
+type DailyUsage struct {
+	Date           string
+	JobSeconds     int
+	TestExecutions int
+}
+
+type organizationUsageDatasource struct {
+	client *Client
+}
+
+type organizationUsageDatasourceModel struct {
+	Period         types.String           `tfsdk:"period"`
+	From           types.String           `tfsdk:"from"`
+	To             types.String           `tfsdk:"to"`
+	JobMinutes     types.Int64            `tfsdk:"job_minutes"`
+	TestExecutions types.Int64            `tfsdk:"test_executions"`
+	Seats          types.Int64            `tfsdk:"seats"`
+	Days           []organizationUsageDay `tfsdk:"days"`
+}
+
+type organizationUsageDay struct {
+	Date           types.String `tfsdk:"date"`
+	JobSeconds     types.Int64  `tfsdk:"job_seconds"`
+	TestExecutions types.Int64  `tfsdk:"test_executions"`
+}
+
+func newOrganizationUsageDatasource() datasource.DataSource {
+	return &organizationUsageDatasource{}
+}
+
+func (o *organizationUsageDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	o.client = req.ProviderData.(*Client)
+}
+
+func (*organizationUsageDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_usage"
+}
+
+func (*organizationUsageDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to read the organization's billable usage for a month, for example to report on build
+			costs.
+
+			Job minutes and test executions are limited to the month. Buildkite doesn't report past membership, so the
+			number of seats is always the current number of members.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"period": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The month to read usage for, e.g. `2024-05`.",
+			},
+			"from": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The first day of the month.",
+			},
+			"to": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The last day of the month.",
+			},
+			"job_minutes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The job minutes used by every pipeline in the month, rounded down to whole minutes as they're billed.",
+			},
+			"test_executions": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of test executions recorded by every test suite in the month.",
+			},
+			"seats": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of members the organization has now.",
+			},
+			"days": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The usage of each day of the month that had any, in date order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The day, e.g. `2024-05-01`.",
+						},
+						"job_seconds": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The job time used by every pipeline on the day, in seconds.",
+						},
+						"test_executions": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of test executions recorded on the day.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (o *organizationUsageDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state organizationUsageDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usage, err := o.client.GetOrganizationUsage(ctx, state.Period.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read organization usage",
+			fmt.Sprintf("Unable to read organization usage: %s", err.Error()),
+		)
+		return
+	}
+
+	state.From = types.StringValue(usage.From)
+	state.To = types.StringValue(usage.To)
+	state.JobMinutes = types.Int64Value(int64(usage.JobMinutes))
+	state.TestExecutions = types.Int64Value(int64(usage.TestExecutions))
+	state.Seats = types.Int64Value(int64(usage.Seats))
+	state.Days = make([]organizationUsageDay, len(usage.Days))
+	for i, day := range usage.Days {
+		state.Days[i] = organizationUsageDay{
+			Date:           types.StringValue(day.Date),
+			JobSeconds:     types.Int64Value(int64(day.JobSeconds)),
+			TestExecutions: types.Int64Value(int64(day.TestExecutions)),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetOrganizationUsage returns the organization's job minutes and test executions for a month, given like "2024-05",
+// along with its current number of members. Buildkite records usage per pipeline and suite for each day, which is
+// summed here.
+func (client *Client) GetOrganizationUsage(ctx context.Context, period string) (Usage, error) {
+	month, err := time.Parse("2006-01", period)
+	if err != nil {
+		return Usage{}, fmt.Errorf("invalid period %q, expected a month like 2024-05", period)
+	}
+	usage := Usage{
+		From: month.Format(time.DateOnly),
+		To:   month.AddDate(0, 1, -1).Format(time.DateOnly),
+	}
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return Usage{}, err
+	}
+
+	days, err := paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]DailyUsage, pageInfo, error) {
+		r, err := getOrganizationUsage(ctx, client.genqlient, client.organization, usage.From, usage.To, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		usage.Seats = r.Organization.Members.Count
+		var days []DailyUsage
+		for _, edge := range r.Organization.Usage.Edges {
+			switch node := edge.Node.(type) {
+			case *getOrganizationUsageOrganizationUsageUsageUnionConnectionEdgesUsageUnionEdgeNodeJobMinutesUsage:
+				days = append(days, DailyUsage{Date: node.AggregatedOn, JobSeconds: node.Seconds})
+			case *getOrganizationUsageOrganizationUsageUsageUnionConnectionEdgesUsageUnionEdgeNodeTestExecutionsUsage:
+				days = append(days, DailyUsage{Date: node.AggregatedOn, TestExecutions: node.Executions})
+			}
+		}
+		return days, &r.Organization.Usage.PageInfo, nil
+	})
+	if err != nil {
+		return Usage{}, err
+	}
+
+	byDate := map[string]*DailyUsage{}
+	var jobSeconds int
+	for _, day := range days {
+		jobSeconds += day.JobSeconds
+		usage.TestExecutions += day.TestExecutions
+
+		total, ok := byDate[day.Date]
+		if !ok {
+			total = &DailyUsage{Date: day.Date}
+			byDate[day.Date] = total
+		}
+		total.JobSeconds += day.JobSeconds
+		total.TestExecutions += day.TestExecutions
+	}
+	usage.JobMinutes = jobSeconds / 60
+
+	for _, day := range byDate {
+		usage.Days = append(usage.Days, *day)
+	}
+	// dates are formatted so they sort in order
+	sort.Slice(usage.Days, func(i, j int) bool {
+		return usage.Days[i].Date < usage.Days[j].Date
+	})
+	return usage, nil
+}
@@ -0,0 +1,51 @@
+package buildkite
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetOrganizationUsage(t *testing.T) {
+	t.Parallel()
+
+	var page int
+	client := newTestGraphqlClient(t, func(operation string) string {
+		page++
+		if page == 1 {
+			return `{"data": {"organization": {"members": {"count": 12}, "usage": {
+				"pageInfo": {"endCursor": "first", "hasNextPage": true},
+				"edges": [
+					{"node": {"__typename": "JobMinutesUsage", "aggregatedOn": "2024-05-02", "seconds": 90}},
+					{"node": {"__typename": "JobMinutesUsage", "aggregatedOn": "2024-05-01", "seconds": 45}}
+				]
+			}}}}`
+		}
+		return `{"data": {"organization": {"members": {"count": 12}, "usage": {
+			"pageInfo": {"endCursor": "second", "hasNextPage": false},
+			"edges": [
+				{"node": {"__typename": "JobMinutesUsage", "aggregatedOn": "2024-05-02", "seconds": 30}},
+				{"node": {"__typename": "TestExecutionsUsage", "aggregatedOn": "2024-05-02", "executions": 400}}
+			]
+		}}}}`
+	})
+
+	usage, err := client.GetOrganizationUsage(context.Background(), "2024-05")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if usage.From != "2024-05-01" || usage.To != "2024-05-31" {
+		t.Errorf("unexpected period %s to %s", usage.From, usage.To)
+	}
+	// 165 seconds is billed as 2 minutes
+	if usage.JobMinutes != 2 || usage.TestExecutions != 400 || usage.Seats != 12 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if len(usage.Days) != 2 || usage.Days[0].Date != "2024-05-01" || usage.Days[1].JobSeconds != 120 || usage.Days[1].TestExecutions != 400 {
+		t.Errorf("unexpected days: %+v", usage.Days)
+	}
+
+	if _, err := client.GetOrganizationUsage(context.Background(), "May 2024"); err == nil {
+		t.Error("expected an invalid period to fail")
+	}
+}
@@ -2,25 +2,42 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type pipelineDataSourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	DefaultBranch types.String `tfsdk:"default_branch"`
-	Description   types.String `tfsdk:"description"`
-	Repository    types.String `tfsdk:"repository"`
-	Slug          types.String `tfsdk:"slug"`
-	WebhookUrl    types.String `tfsdk:"webhook_url"`
+	ID                  types.String       `tfsdk:"id"`
+	Name                types.String       `tfsdk:"name"`
+	DefaultBranch       types.String       `tfsdk:"default_branch"`
+	Description         types.String       `tfsdk:"description"`
+	Repository          types.String       `tfsdk:"repository"`
+	Slug                types.String       `tfsdk:"slug"`
+	WebhookUrl          types.String       `tfsdk:"webhook_url"`
+	IncludeRecentBuilds types.Bool         `tfsdk:"include_recent_builds"`
+	RecentBuildsLimit   types.Int64        `tfsdk:"recent_builds_limit"`
+	RecentBuilds        []recentBuildModel `tfsdk:"recent_builds"`
+	IncludeLastBuild    types.Bool         `tfsdk:"include_last_build"`
+	LastBuild           *recentBuildModel  `tfsdk:"last_build"`
 }
 
+type recentBuildModel struct {
+	Number types.Int64  `tfsdk:"number"`
+	State  types.String `tfsdk:"state"`
+	Branch types.String `tfsdk:"branch"`
+}
+
+// defaultRecentBuildsLimit is how many recent builds are read when recent_builds_limit isn't set
+const defaultRecentBuildsLimit = 5
+
 type pipelineDatasource struct {
 	client *Client
 }
@@ -75,7 +92,61 @@ func (*pipelineDatasource) Schema(ctx context.Context, req datasource.SchemaRequ
 			},
 			"webhook_url": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The Buildkite webhook URL that triggers builds on this pipeline.",
+				Sensitive:           true,
+				MarkdownDescription: "The Buildkite webhook URL that triggers builds on this pipeline. This is sensitive as it contains a token allowing builds to be triggered.",
+			},
+			"include_recent_builds": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to read the pipeline's most recent builds into `recent_builds`. This makes an extra API request, so is off by default.",
+			},
+			"recent_builds_limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The number of recent builds to read when `include_recent_builds` is set. Defaults to %d.", defaultRecentBuildsLimit),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"recent_builds": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The pipeline's most recently created builds, newest first. Only set when `include_recent_builds` is true.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"number": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of the build.",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The state of the build.",
+						},
+						"branch": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The branch the build ran on.",
+						},
+					},
+				},
+			},
+			"include_last_build": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to read the pipeline's most recent build into `last_build`. This makes an extra API request, so is off by default.",
+			},
+			"last_build": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The pipeline's most recently created build. Only set when `include_last_build` is true and the pipeline has been built.",
+				Attributes: map[string]schema.Attribute{
+					"number": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "The number of the build.",
+					},
+					"state": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The state of the build, e.g. `passed` when the pipeline is green.",
+					},
+					"branch": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The branch the build ran on.",
+					},
+				},
 			},
 		},
 	}
@@ -119,5 +190,50 @@ func (c *pipelineDatasource) Read(ctx context.Context, req datasource.ReadReques
 	state.Slug = types.StringValue(pipeline.Pipeline.Slug)
 	state.WebhookUrl = types.StringValue(pipeline.Pipeline.WebhookURL)
 
+	if state.IncludeRecentBuilds.ValueBool() {
+		limit := defaultRecentBuildsLimit
+		if !state.RecentBuildsLimit.IsNull() {
+			limit = int(state.RecentBuildsLimit.ValueInt64())
+		}
+
+		builds, err := c.client.RecentBuilds(ctx, pipeline.Pipeline.Slug, BuildFilter{}, limit)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read recent builds",
+				fmt.Sprintf("Unable to read recent builds: %s", err.Error()),
+			)
+			return
+		}
+
+		state.RecentBuilds = make([]recentBuildModel, len(builds))
+		for i, build := range builds {
+			state.RecentBuilds[i] = recentBuildModel{
+				Number: types.Int64Value(int64(build.Number)),
+				State:  types.StringValue(build.State),
+				Branch: types.StringValue(build.Branch),
+			}
+		}
+	}
+
+	if state.IncludeLastBuild.ValueBool() {
+		build, err := c.client.LatestBuild(ctx, pipeline.Pipeline.Slug, BuildFilter{})
+		switch {
+		case errors.Is(err, ErrNotFound):
+			// a pipeline that's never been built has no last build
+		case err != nil:
+			resp.Diagnostics.AddError(
+				"Unable to read last build",
+				fmt.Sprintf("Unable to read last build: %s", err.Error()),
+			)
+			return
+		default:
+			state.LastBuild = &recentBuildModel{
+				Number: types.Int64Value(int64(build.Number)),
+				State:  types.StringValue(build.State),
+				Branch: types.StringValue(build.Branch),
+			}
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
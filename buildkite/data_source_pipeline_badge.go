@@ -0,0 +1,122 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// BadgeInfo is a pipeline's build status badge. The badge URL contains a token that lets anyone with it see the
+// pipeline's build state, so it should be kept out of logs.
+type BadgeInfo struct {
+	URL string
+	// Markdown shows the badge linked to the pipeline, for embedding in a README
+	Markdown string
+}
+
+type pipelineBadgeDatasource struct {
+	client *Client
+}
+
+type pipelineBadgeDatasourceModel struct {
+	Slug     types.String `tfsdk:"slug"`
+	URL      types.String `tfsdk:"url"`
+	Markdown types.String `tfsdk:"markdown"`
+}
+
+func newPipelineBadgeDatasource() datasource.DataSource {
+	return &pipelineBadgeDatasource{}
+}
+
+func (p *pipelineBadgeDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p.client = req.ProviderData.(*Client)
+}
+
+func (*pipelineBadgeDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_badge"
+}
+
+func (*pipelineBadgeDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to read a pipeline's build status badge, for example to embed it in a README.
+
+			The badge URL contains a token, so it's marked as sensitive.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the pipeline.",
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The URL of the badge image showing the state of the pipeline's latest build.",
+			},
+			"markdown": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Markdown showing the badge linked to the pipeline.",
+			},
+		},
+	}
+}
+
+func (p *pipelineBadgeDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pipelineBadgeDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	badge, err := p.client.GetPipelineBadge(ctx, state.Slug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read pipeline badge",
+			fmt.Sprintf("Unable to read pipeline badge: %s", err.Error()),
+		)
+		return
+	}
+
+	state.URL = types.StringValue(badge.URL)
+	state.Markdown = types.StringValue(badge.Markdown)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetPipelineBadge returns the build status badge of the pipeline with the given slug. GraphQL doesn't expose the
+// badge, so it's read from the REST API.
+func (client *Client) GetPipelineBadge(ctx context.Context, slug string) (BadgeInfo, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return BadgeInfo{}, err
+	}
+
+	var pipeline struct {
+		BadgeURL string `json:"badge_url"`
+		WebURL   string `json:"web_url"`
+	}
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/organizations/%s/pipelines/%s", client.organization, slug), nil, &pipeline)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return BadgeInfo{}, err
+	}
+
+	return BadgeInfo{
+		URL:      pipeline.BadgeURL,
+		Markdown: fmt.Sprintf("[![Build status](%s)](%s)", pipeline.BadgeURL, pipeline.WebURL),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetPipelineBadge(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/organizations/test-org/pipelines/monolith" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"slug": "monolith", "badge_url": "https://badge.buildkite.com/abc123.svg", "web_url": "https://buildkite.com/test-org/monolith"}`))
+	})
+
+	badge, err := client.GetPipelineBadge(context.Background(), "monolith")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if badge.URL != "https://badge.buildkite.com/abc123.svg" {
+		t.Errorf("unexpected badge URL %s", badge.URL)
+	}
+	if badge.Markdown != "[![Build status](https://badge.buildkite.com/abc123.svg)](https://buildkite.com/test-org/monolith)" {
+		t.Errorf("unexpected markdown %s", badge.Markdown)
+	}
+}
@@ -0,0 +1,166 @@
+package buildkite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+type pipelineExportDatasource struct {
+	client *Client
+}
+
+type pipelineExportDatasourceModel struct {
+	Slug  types.String `tfsdk:"slug"`
+	Steps types.String `tfsdk:"steps"`
+}
+
+func newPipelineExportDatasource() datasource.DataSource {
+	return &pipelineExportDatasource{}
+}
+
+func (p *pipelineExportDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p.client = req.ProviderData.(*Client)
+}
+
+func (*pipelineExportDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_export"
+}
+
+func (*pipelineExportDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to export the steps of a pipeline as YAML, for example to back them up in git or to
+			migrate the pipeline to another organization.
+
+			The YAML is normalized, with keys sorted and comments removed, so the output only changes when the steps do.
+		`),
+		Attributes: map[string]schema.Attribute{
+			"slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the pipeline to export.",
+			},
+			"steps": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The pipeline's steps as normalized YAML.",
+			},
+		},
+	}
+}
+
+func (p *pipelineExportDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pipelineExportDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	steps, err := p.client.ExportPipeline(ctx, state.Slug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to export pipeline",
+			fmt.Sprintf("Unable to export pipeline: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Steps = types.StringValue(steps)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ExportPipeline returns the steps of the pipeline with the given slug as normalized YAML
+func (client *Client) ExportPipeline(ctx context.Context, slug string) (string, error) {
+	pipeline, err := client.getPipelineBySlug(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+
+	steps, err := normalizeYAML(pipeline.Steps.Yaml)
+	if err != nil {
+		return "", fmt.Errorf("pipeline %s has invalid steps: %w", slug, err)
+	}
+	return steps, nil
+}
+
+// normalizeYAML re-encodes a YAML document with sorted keys, two space indentation and no comments, so equivalent
+// documents always produce the same output
+func normalizeYAML(document string) (string, error) {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(document), &value); err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// PipelineExport is a pipeline's configuration as exported by ExportAllPipelines
+type PipelineExport struct {
+	Slug       string
+	Name       string
+	Repository string
+	// Steps is the pipeline's steps as normalized YAML
+	Steps string
+}
+
+// ExportAllPipelines returns the configuration of every pipeline in the organization, ordered by name. Steps are
+// fetched along with each page of pipelines rather than one pipeline at a time, keeping the number of requests (and
+// so the chance of being rate limited) low even for large organizations. Pipelines whose steps can't be parsed are
+// left out with a warning, so one broken pipeline doesn't stop the rest being exported.
+func (client *Client) ExportAllPipelines(ctx context.Context) ([]PipelineExport, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]PipelineExport, pageInfo, error) {
+		r, err := listPipelineExports(ctx, client.genqlient, client.organization, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var exports []PipelineExport
+		for _, edge := range r.Organization.Pipelines.Edges {
+			steps, err := normalizeYAML(edge.Node.Steps.Yaml)
+			if err != nil {
+				tflog.Warn(ctx, "Leaving pipeline with invalid steps out of the export", map[string]interface{}{
+					"pipeline": edge.Node.Slug,
+					"error":    err.Error(),
+				})
+				continue
+			}
+			exports = append(exports, PipelineExport{
+				Slug:       edge.Node.Slug,
+				Name:       edge.Node.Name,
+				Repository: edge.Node.Repository.Url,
+				Steps:      steps,
+			})
+		}
+
+		return exports, &r.Organization.Pipelines.PageInfo, nil
+	})
+}
@@ -0,0 +1,57 @@
+package buildkite
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExportPipeline(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGraphqlClient(t, func(operation string) string {
+		return `{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "steps": {"yaml": "# build it\nsteps:\n    - label: \"Build\"\n      command: make\n"}}}}`
+	})
+
+	steps, err := client.ExportPipeline(context.Background(), "deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "steps:\n  - command: make\n    label: Build\n"
+	if steps != expected {
+		t.Errorf("expected normalized steps %q, got %q", expected, steps)
+	}
+}
+
+func TestExportAllPipelines(t *testing.T) {
+	t.Parallel()
+
+	var page int
+	client := newTestGraphqlClient(t, func(operation string) string {
+		page++
+		if page == 1 {
+			return `{"data": {"organization": {"pipelines": {
+				"pageInfo": {"endCursor": "first", "hasNextPage": true},
+				"edges": [{"node": {"slug": "alpha", "repository": {"url": "git@github.com:org/alpha.git"}, "steps": {"yaml": "steps: []"}}}]
+			}}}}`
+		}
+		return `{"data": {"organization": {"pipelines": {
+			"pageInfo": {"endCursor": "second", "hasNextPage": false},
+			"edges": [
+				{"node": {"slug": "beta", "repository": {"url": "git@github.com:org/beta.git"}, "steps": {"yaml": "steps:\n- command: make"}}},
+				{"node": {"slug": "broken", "repository": {"url": "git@github.com:org/broken.git"}, "steps": {"yaml": "steps: ["}}}
+			]
+		}}}}`
+	})
+
+	exports, err := client.ExportAllPipelines(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(exports) != 2 {
+		t.Fatalf("expected the valid pipelines from both pages, got %d", len(exports))
+	}
+	if exports[1].Slug != "beta" || exports[1].Steps != "steps:\n  - command: make\n" {
+		t.Errorf("unexpected export: %+v", exports[1])
+	}
+}
@@ -0,0 +1,162 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// integrationBuildsChecked is how many of a pipeline's most recent builds are searched for one triggered by a webhook
+const integrationBuildsChecked = 50
+
+// PipelineIntegrationStatus describes a pipeline's connection to its source code provider. Buildkite doesn't report
+// webhook deliveries directly, so the most recent build triggered by a webhook stands in for the last delivery.
+type PipelineIntegrationStatus struct {
+	// Provider is the name of the repository provider, e.g. "GitHub"
+	Provider    string
+	ProviderURL *string
+	// LastWebhookBuildNumber and LastWebhookBuildAt are nil when none of the pipeline's recent builds were triggered by
+	// a webhook
+	LastWebhookBuildNumber *int
+	LastWebhookBuildAt     *time.Time
+}
+
+type pipelineIntegrationDatasource struct {
+	client *Client
+}
+
+type pipelineIntegrationDatasourceModel struct {
+	Slug                   types.String `tfsdk:"slug"`
+	RepositoryProvider     types.String `tfsdk:"repository_provider"`
+	ProviderURL            types.String `tfsdk:"provider_url"`
+	LastWebhookBuildNumber types.Int64  `tfsdk:"last_webhook_build_number"`
+	LastWebhookBuildAt     types.String `tfsdk:"last_webhook_build_at"`
+}
+
+func newPipelineIntegrationDatasource() datasource.DataSource {
+	return &pipelineIntegrationDatasource{}
+}
+
+func (p *pipelineIntegrationDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p.client = req.ProviderData.(*Client)
+}
+
+func (*pipelineIntegrationDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_integration"
+}
+
+func (*pipelineIntegrationDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(fmt.Sprintf(`
+			Use this data source to check a pipeline's source code provider integration, for example to alert when
+			webhooks have stopped triggering builds.
+
+			Buildkite doesn't report webhook deliveries directly, so the most recent build triggered by a webhook is
+			used instead. Only the pipeline's %d most recent builds are checked.
+		`, integrationBuildsChecked)),
+		Attributes: map[string]schema.Attribute{
+			"slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the pipeline.",
+			},
+			"repository_provider": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the pipeline's repository provider, e.g. `GitHub`.",
+			},
+			"provider_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL of the repository on the provider's website, if known.",
+			},
+			"last_webhook_build_number": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of the most recent build triggered by a webhook. Null if none of the recent builds were.",
+			},
+			"last_webhook_build_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the most recent build triggered by a webhook was created, as an RFC3339 timestamp. Null if none of the recent builds were.",
+			},
+		},
+	}
+}
+
+func (p *pipelineIntegrationDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pipelineIntegrationDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := p.client.GetPipelineIntegrationStatus(ctx, state.Slug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read pipeline integration",
+			fmt.Sprintf("Unable to read pipeline integration: %s", err.Error()),
+		)
+		return
+	}
+
+	state.RepositoryProvider = types.StringValue(status.Provider)
+	state.ProviderURL = types.StringPointerValue(status.ProviderURL)
+	state.LastWebhookBuildNumber = types.Int64Null()
+	state.LastWebhookBuildAt = types.StringNull()
+	if status.LastWebhookBuildNumber != nil {
+		state.LastWebhookBuildNumber = types.Int64Value(int64(*status.LastWebhookBuildNumber))
+	}
+	if status.LastWebhookBuildAt != nil {
+		state.LastWebhookBuildAt = types.StringValue(status.LastWebhookBuildAt.Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetPipelineIntegrationStatus returns the repository provider of the pipeline with the given slug, along with its most
+// recent build triggered by a webhook. Returns ErrNotFound if the pipeline doesn't exist.
+func (client *Client) GetPipelineIntegrationStatus(ctx context.Context, slug string) (PipelineIntegrationStatus, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return PipelineIntegrationStatus{}, err
+	}
+
+	var r *getPipelineIntegrationResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getPipelineIntegration(ctx, client.genqlient, fmt.Sprintf("%s/%s", client.organization, slug), integrationBuildsChecked)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return PipelineIntegrationStatus{}, err
+	}
+
+	if r.Pipeline.Id == "" {
+		return PipelineIntegrationStatus{}, fmt.Errorf("pipeline %s: %w", slug, ErrNotFound)
+	}
+
+	var status PipelineIntegrationStatus
+	if provider := r.Pipeline.Repository.Provider; provider != nil {
+		status.Provider = provider.GetName()
+		status.ProviderURL = provider.GetUrl()
+	}
+
+	// builds are returned newest first
+	for _, edge := range r.Pipeline.Builds.Edges {
+		if _, ok := edge.Node.Source.(*getPipelineIntegrationPipelineBuildsBuildConnectionEdgesBuildEdgeNodeBuildSourceBuildSourceWebhook); ok {
+			number := edge.Node.Number
+			status.LastWebhookBuildNumber = &number
+			status.LastWebhookBuildAt = edge.Node.CreatedAt
+			break
+		}
+	}
+
+	return status, nil
+}
@@ -0,0 +1,65 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetPipelineIntegrationStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the most recent webhook build", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": {
+				"id": "UGlwZWxpbmU=",
+				"repository": {"provider": {"__typename": "RepositoryProviderGithub", "name": "GitHub", "url": "https://github.com/buildkite/deploy"}},
+				"builds": {"edges": [
+					{"node": {"number": 12, "createdAt": "2023-10-03T00:00:00Z", "source": {"__typename": "BuildSourceSchedule", "name": "Schedule"}}},
+					{"node": {"number": 11, "createdAt": "2023-10-02T00:00:00Z", "source": {"__typename": "BuildSourceWebhook", "name": "Webhook"}}},
+					{"node": {"number": 10, "createdAt": "2023-10-01T00:00:00Z", "source": {"__typename": "BuildSourceWebhook", "name": "Webhook"}}}
+				]}
+			}}}`
+		})
+
+		status, err := client.GetPipelineIntegrationStatus(context.Background(), "deploy")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if status.Provider != "GitHub" {
+			t.Errorf("expected the GitHub provider, got %s", status.Provider)
+		}
+		if status.LastWebhookBuildNumber == nil || *status.LastWebhookBuildNumber != 11 {
+			t.Errorf("expected build 11 to be the last webhook build, got %v", status.LastWebhookBuildNumber)
+		}
+	})
+
+	t.Run("leaves the last delivery unknown without webhook builds", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": {
+				"id": "UGlwZWxpbmU=",
+				"repository": {"provider": {"__typename": "RepositoryProviderUnknown", "name": "Unknown", "url": null}},
+				"builds": {"edges": [{"node": {"number": 1, "createdAt": "2023-10-01T00:00:00Z", "source": {"__typename": "BuildSourceAPI", "name": "API"}}}]}
+			}}}`
+		})
+
+		status, err := client.GetPipelineIntegrationStatus(context.Background(), "deploy")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if status.LastWebhookBuildNumber != nil || status.LastWebhookBuildAt != nil {
+			t.Errorf("expected no webhook build, got %+v", status)
+		}
+	})
+
+	t.Run("returns ErrNotFound for a missing pipeline", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": null}}`
+		})
+
+		_, err := client.GetPipelineIntegrationStatus(context.Background(), "deploy")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
@@ -37,6 +37,7 @@ func TestAccBuildkitePipelineDataSource(t *testing.T) {
 
 						data "buildkite_pipeline" "pipeline" {
 							slug = buildkite_pipeline.pipeline.slug
+							include_last_build = true
 						}
 					`, pipelineName),
 					Check: resource.ComposeAggregateTestCheckFunc(
@@ -46,6 +47,8 @@ func TestAccBuildkitePipelineDataSource(t *testing.T) {
 						resource.TestCheckResourceAttr("data.buildkite_pipeline.pipeline", "name", pipelineName),
 						resource.TestCheckResourceAttr("data.buildkite_pipeline.pipeline", "repository", "https://github.com/buildkite/terraform-provider-buildkite.git"),
 						resource.TestCheckResourceAttrPair("data.buildkite_pipeline.pipeline", "id", "buildkite_pipeline.pipeline", "id"),
+						// A new pipeline hasn't been built yet
+						resource.TestCheckNoResourceAttr("data.buildkite_pipeline.pipeline", "last_build.number"),
 					),
 				},
 			},
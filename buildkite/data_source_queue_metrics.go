@@ -0,0 +1,208 @@
+package buildkite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// QueueMetrics are the agent and job counts of a single queue
+type QueueMetrics struct {
+	IdleAgents    int
+	BusyAgents    int
+	TotalAgents   int
+	ScheduledJobs int
+	RunningJobs   int
+	WaitingJobs   int
+	TotalJobs     int
+}
+
+// agentMetrics is the response of the agent API metrics endpoint, with counts broken down by queue
+type agentMetrics struct {
+	Agents struct {
+		Queues map[string]struct {
+			Idle  int `json:"idle"`
+			Busy  int `json:"busy"`
+			Total int `json:"total"`
+		} `json:"queues"`
+	} `json:"agents"`
+	Jobs struct {
+		Queues map[string]struct {
+			Scheduled int `json:"scheduled"`
+			Running   int `json:"running"`
+			Waiting   int `json:"waiting"`
+			Total     int `json:"total"`
+		} `json:"queues"`
+	} `json:"jobs"`
+}
+
+type queueMetricsDatasource struct {
+	client *Client
+}
+
+type queueMetricsDatasourceModel struct {
+	AgentToken    types.String `tfsdk:"agent_token"`
+	Queue         types.String `tfsdk:"queue"`
+	IdleAgents    types.Int64  `tfsdk:"idle_agents"`
+	BusyAgents    types.Int64  `tfsdk:"busy_agents"`
+	TotalAgents   types.Int64  `tfsdk:"total_agents"`
+	ScheduledJobs types.Int64  `tfsdk:"scheduled_jobs"`
+	RunningJobs   types.Int64  `tfsdk:"running_jobs"`
+	WaitingJobs   types.Int64  `tfsdk:"waiting_jobs"`
+	TotalJobs     types.Int64  `tfsdk:"total_jobs"`
+}
+
+func newQueueMetricsDatasource() datasource.DataSource {
+	return &queueMetricsDatasource{}
+}
+
+func (q *queueMetricsDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	q.client = req.ProviderData.(*Client)
+}
+
+func (*queueMetricsDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queue_metrics"
+}
+
+func (*queueMetricsDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to read the current agent and job counts of a queue, for example to size agent
+			autoscaling groups.
+
+			The metrics come from the agent API, so they are read with an agent token rather than the provider's API
+			token. More info in the Buildkite [documentation](https://buildkite.com/docs/apis/agent-api/metrics).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"agent_token": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "An agent token for the agents in the queue.",
+			},
+			"queue": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the queue.",
+			},
+			"idle_agents": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of connected agents that aren't running a job.",
+			},
+			"busy_agents": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of connected agents running a job.",
+			},
+			"total_agents": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of connected agents.",
+			},
+			"scheduled_jobs": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of jobs waiting for an agent.",
+			},
+			"running_jobs": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of jobs running on an agent.",
+			},
+			"waiting_jobs": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of jobs waiting on other steps before they can be scheduled.",
+			},
+			"total_jobs": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of jobs in the queue.",
+			},
+		},
+	}
+}
+
+func (q *queueMetricsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state queueMetricsDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metrics, err := q.client.GetQueueMetrics(ctx, state.AgentToken.ValueString(), state.Queue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read queue metrics",
+			fmt.Sprintf("Unable to read queue metrics: %s", err.Error()),
+		)
+		return
+	}
+
+	state.IdleAgents = types.Int64Value(int64(metrics.IdleAgents))
+	state.BusyAgents = types.Int64Value(int64(metrics.BusyAgents))
+	state.TotalAgents = types.Int64Value(int64(metrics.TotalAgents))
+	state.ScheduledJobs = types.Int64Value(int64(metrics.ScheduledJobs))
+	state.RunningJobs = types.Int64Value(int64(metrics.RunningJobs))
+	state.WaitingJobs = types.Int64Value(int64(metrics.WaitingJobs))
+	state.TotalJobs = types.Int64Value(int64(metrics.TotalJobs))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetQueueMetrics returns the agent and job counts of a queue from the agent API, authenticating with the given agent
+// token. A queue that isn't in the response, because it has no agents or jobs or the name is wrong, returns ErrNotFound
+// rather than zero counts that would look like an idle queue.
+func (client *Client) GetQueueMetrics(ctx context.Context, agentToken, queue string) (QueueMetrics, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return QueueMetrics{}, err
+	}
+
+	var metrics agentMetrics
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		return retryContextError(ctx, client.getAgentMetrics(ctx, agentToken, &metrics))
+	})
+	if err != nil {
+		return QueueMetrics{}, err
+	}
+
+	agents, hasAgents := metrics.Agents.Queues[queue]
+	jobs, hasJobs := metrics.Jobs.Queues[queue]
+	if !hasAgents && !hasJobs {
+		return QueueMetrics{}, fmt.Errorf("queue %s: %w", queue, ErrNotFound)
+	}
+	return QueueMetrics{
+		IdleAgents:    agents.Idle,
+		BusyAgents:    agents.Busy,
+		TotalAgents:   agents.Total,
+		ScheduledJobs: jobs.Scheduled,
+		RunningJobs:   jobs.Running,
+		WaitingJobs:   jobs.Waiting,
+		TotalJobs:     jobs.Total,
+	}, nil
+}
+
+func (client *Client) getAgentMetrics(ctx context.Context, agentToken string, metrics *agentMetrics) error {
+	url := client.agentUrl + "/metrics"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+agentToken)
+
+	resp, err := client.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return &apiError{Method: http.MethodGet, URL: url, StatusCode: resp.StatusCode}
+	}
+	return json.NewDecoder(resp.Body).Decode(metrics)
+}
@@ -0,0 +1,46 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetQueueMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" || r.Header.Get("Authorization") != "Token agent-token" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{
+			"agents": {"idle": 3, "busy": 2, "total": 5, "queues": {"deploy": {"idle": 1, "busy": 1, "total": 2}}},
+			"jobs": {"scheduled": 4, "running": 2, "waiting": 1, "total": 7, "queues": {"deploy": {"scheduled": 3, "running": 1, "waiting": 0, "total": 4}}},
+			"organization": {"slug": "test-org"}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// the API token would otherwise replace the agent token
+	header := http.Header{"Authorization": []string{"Bearer api-token"}}
+	client := &Client{
+		http:     &http.Client{Transport: newHeaderRoundTripper(server.Client().Transport, header)},
+		agentUrl: server.URL,
+	}
+
+	metrics, err := client.GetQueueMetrics(context.Background(), "agent-token", "deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := QueueMetrics{IdleAgents: 1, BusyAgents: 1, TotalAgents: 2, ScheduledJobs: 3, RunningJobs: 1, TotalJobs: 4}
+	if metrics != expected {
+		t.Errorf("expected %+v, got %+v", expected, metrics)
+	}
+
+	_, err = client.GetQueueMetrics(context.Background(), "agent-token", "unknown")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a queue missing from the response, got %v", err)
+	}
+}
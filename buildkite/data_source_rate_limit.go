@@ -0,0 +1,150 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// RateLimitInfo is the REST or GraphQL API rate limit status of the organization, as reported in response headers
+type RateLimitInfo struct {
+	// Limited is false when the response had no rate limit headers, in which case the other fields are zero
+	Limited   bool
+	Limit     int
+	Remaining int
+	// ResetAt is when the current rate limit window ends and Remaining resets to Limit
+	ResetAt time.Time
+}
+
+type rateLimitDatasource struct {
+	client *Client
+}
+
+type rateLimitDatasourceModel struct {
+	Limit     types.Int64  `tfsdk:"limit"`
+	Remaining types.Int64  `tfsdk:"remaining"`
+	ResetAt   types.String `tfsdk:"reset_at"`
+}
+
+func newRateLimitDatasource() datasource.DataSource {
+	return &rateLimitDatasource{}
+}
+
+func (r *rateLimitDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+func (*rateLimitDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rate_limit"
+}
+
+func (*rateLimitDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to check how much of the organization's REST API rate limit is left, for example
+			before starting a large batch of changes.
+
+			The attributes are null if Buildkite doesn't report a rate limit. More info in the Buildkite
+			[documentation](https://buildkite.com/docs/apis/rest-api/limits).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of requests allowed in each rate limit window.",
+			},
+			"remaining": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of requests left in the current window.",
+			},
+			"reset_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the current window ends, as an RFC3339 timestamp.",
+			},
+		},
+	}
+}
+
+func (r *rateLimitDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state rateLimitDatasourceModel
+
+	info, err := r.client.GetRateLimitStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read rate limit",
+			fmt.Sprintf("Unable to read rate limit: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Limit = types.Int64Null()
+	state.Remaining = types.Int64Null()
+	state.ResetAt = types.StringNull()
+	if info.Limited {
+		state.Limit = types.Int64Value(int64(info.Limit))
+		state.Remaining = types.Int64Value(int64(info.Remaining))
+		state.ResetAt = types.StringValue(info.ResetAt.Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// GetRateLimitStatus returns the organization's REST API rate limit status. It's read from the headers of a request
+// for the API token's own details, which is about as cheap as an authenticated request gets, though it does count
+// towards the limit.
+func (client *Client) GetRateLimitStatus(ctx context.Context) (RateLimitInfo, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+
+	var header http.Header
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var token struct {
+			UUID string `json:"uuid"`
+		}
+		var err error
+		header, err = client.doRequest(ctx, http.MethodGet, "/v2/access-token", nil, &token)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+
+	return parseRateLimit(header, time.Now())
+}
+
+// parseRateLimit reads the RateLimit headers of a REST response, where RateLimit-Reset is the number of seconds until
+// the window resets
+func parseRateLimit(header http.Header, now time.Time) (RateLimitInfo, error) {
+	if header.Get("RateLimit-Limit") == "" {
+		return RateLimitInfo{}, nil
+	}
+
+	values := map[string]int{}
+	for _, name := range []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"} {
+		value, err := strconv.Atoi(header.Get(name))
+		if err != nil {
+			return RateLimitInfo{}, fmt.Errorf("invalid %s header %q", name, header.Get(name))
+		}
+		values[name] = value
+	}
+
+	return RateLimitInfo{
+		Limited:   true,
+		Limit:     values["RateLimit-Limit"],
+		Remaining: values["RateLimit-Remaining"],
+		ResetAt:   now.Add(time.Duration(values["RateLimit-Reset"]) * time.Second).Truncate(time.Second),
+	}, nil
+}
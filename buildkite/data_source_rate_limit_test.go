@@ -0,0 +1,50 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetRateLimitStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads the rate limit headers", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v2/access-token" {
+				t.Errorf("unexpected request: %s", r.URL.Path)
+			}
+			w.Header().Set("RateLimit-Limit", "200")
+			w.Header().Set("RateLimit-Remaining", "150")
+			w.Header().Set("RateLimit-Reset", "30")
+			w.Write([]byte(`{"uuid": "b63254c0-3271-4a98-8270-7cfbd6c2f14e", "scopes": ["read_builds"]}`))
+		})
+
+		before := time.Now()
+		info, err := client.GetRateLimitStatus(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !info.Limited || info.Limit != 200 || info.Remaining != 150 {
+			t.Errorf("unexpected rate limit: %+v", info)
+		}
+		if reset := info.ResetAt.Sub(before); reset < 29*time.Second || reset > 31*time.Second {
+			t.Errorf("expected the limit to reset in 30 seconds, got %s", reset)
+		}
+	})
+
+	t.Run("reports no limit without the headers", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"uuid": "b63254c0-3271-4a98-8270-7cfbd6c2f14e"}`))
+		})
+
+		info, err := client.GetRateLimitStatus(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if info.Limited {
+			t.Errorf("expected no rate limit, got %+v", info)
+		}
+	})
+}
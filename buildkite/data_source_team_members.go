@@ -0,0 +1,170 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TeamMember is a user's membership of a team
+type TeamMember struct {
+	ID        string
+	UUID      string
+	Role      string
+	UserID    string
+	UserName  string
+	UserEmail string
+}
+
+type teamMembersDatasource struct {
+	client *Client
+}
+
+type teamMembersDatasourceModel struct {
+	TeamID  types.String           `tfsdk:"team_id"`
+	Members []teamMembersUserModel `tfsdk:"members"`
+}
+
+type teamMembersUserModel struct {
+	ID        types.String `tfsdk:"id"`
+	UUID      types.String `tfsdk:"uuid"`
+	Role      types.String `tfsdk:"role"`
+	UserID    types.String `tfsdk:"user_id"`
+	UserName  types.String `tfsdk:"user_name"`
+	UserEmail types.String `tfsdk:"user_email"`
+}
+
+func newTeamMembersDatasource() datasource.DataSource {
+	return &teamMembersDatasource{}
+}
+
+func (t *teamMembersDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	t.client = req.ProviderData.(*Client)
+}
+
+func (*teamMembersDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_members"
+}
+
+func (*teamMembersDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to list every member of a team along with their role, for example for access
+			reviews.
+
+			More info in the Buildkite [documentation](https://buildkite.com/docs/team-management/permissions).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"team_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GraphQL ID of the team.",
+			},
+			"members": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The members of the team.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the team membership.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the team membership.",
+						},
+						"role": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The member's role in the team. Either `MEMBER` or `MAINTAINER`.",
+						},
+						"user_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the user.",
+						},
+						"user_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the user.",
+						},
+						"user_email": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The primary email address of the user.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *teamMembersDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state teamMembersDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := t.client.ListTeamMembers(ctx, state.TeamID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read team members",
+			fmt.Sprintf("Unable to read team members: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Members = make([]teamMembersUserModel, len(members))
+	for i, member := range members {
+		state.Members[i] = teamMembersUserModel{
+			ID:        types.StringValue(member.ID),
+			UUID:      types.StringValue(member.UUID),
+			Role:      types.StringValue(member.Role),
+			UserID:    types.StringValue(member.UserID),
+			UserName:  types.StringValue(member.UserName),
+			UserEmail: types.StringValue(member.UserEmail),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ListTeamMembers returns every member of the team with the given GraphQL ID, or ErrNotFound if the team doesn't exist
+func (client *Client) ListTeamMembers(ctx context.Context, teamID string) ([]TeamMember, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]TeamMember, pageInfo, error) {
+		r, err := listTeamMembers(ctx, client.genqlient, teamID, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		team, ok := r.Node.(*listTeamMembersNodeTeam)
+		if !ok {
+			return nil, nil, fmt.Errorf("team %s: %w", teamID, ErrNotFound)
+		}
+
+		var members []TeamMember
+		for _, edge := range team.Members.Edges {
+			members = append(members, TeamMember{
+				ID:        edge.Node.Id,
+				UUID:      edge.Node.Uuid,
+				Role:      edge.Node.Role,
+				UserID:    edge.Node.User.Id,
+				UserName:  edge.Node.User.Name,
+				UserEmail: edge.Node.User.Email,
+			})
+		}
+
+		return members, &team.Members.PageInfo, nil
+	})
+}
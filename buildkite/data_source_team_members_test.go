@@ -0,0 +1,50 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestListTeamMembers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns members from every page", func(t *testing.T) {
+		var page int
+		client := newTestGraphqlClient(t, func(operation string) string {
+			page++
+			if page == 1 {
+				return `{"data": {"node": {"__typename": "Team", "members": {
+					"pageInfo": {"endCursor": "first", "hasNextPage": true},
+					"edges": [{"node": {"id": "a", "uuid": "1", "role": "MAINTAINER", "user": {"id": "VXNlcg==", "name": "Ada", "email": "ada@example.com"}}}]
+				}}}}`
+			}
+			return `{"data": {"node": {"__typename": "Team", "members": {
+				"pageInfo": {"endCursor": "second", "hasNextPage": false},
+				"edges": [{"node": {"id": "b", "uuid": "2", "role": "MEMBER", "user": {"id": "VXNlcjI=", "name": "Grace", "email": "grace@example.com"}}}]
+			}}}}`
+		})
+
+		members, err := client.ListTeamMembers(context.Background(), "VGVhbQ==")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(members) != 2 {
+			t.Fatalf("expected members from both pages, got %+v", members)
+		}
+		if members[1].UserEmail != "grace@example.com" || members[1].Role != "MEMBER" {
+			t.Errorf("unexpected member: %+v", members[1])
+		}
+	})
+
+	t.Run("returns ErrNotFound for a missing team", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"node": null}}`
+		})
+
+		_, err := client.ListTeamMembers(context.Background(), "VGVhbQ==")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
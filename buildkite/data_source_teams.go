@@ -0,0 +1,174 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Team represents a team in a Buildkite organization
+type Team struct {
+	ID                        string
+	UUID                      string
+	Slug                      string
+	Name                      string
+	Description               *string
+	Privacy                   string
+	IsDefaultTeam             bool
+	DefaultMemberRole         string
+	MembersCanCreatePipelines bool
+	MembersCanDeletePipelines bool
+	MemberCount               int
+}
+
+func newTeam(fields TeamFields) Team {
+	return Team{
+		ID:                        fields.Id,
+		UUID:                      fields.Uuid,
+		Slug:                      fields.Slug,
+		Name:                      fields.Name,
+		Description:               fields.Description,
+		Privacy:                   fields.Privacy,
+		IsDefaultTeam:             fields.IsDefaultTeam,
+		DefaultMemberRole:         fields.DefaultMemberRole,
+		MembersCanCreatePipelines: fields.MembersCanCreatePipelines,
+		MembersCanDeletePipelines: fields.MembersCanDeletePipelines,
+	}
+}
+
+type teamsDatasource struct {
+	client *Client
+}
+
+type teamsDatasourceModel struct {
+	Teams []teamsTeamModel `tfsdk:"teams"`
+}
+
+type teamsTeamModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	Slug        types.String `tfsdk:"slug"`
+	Name        types.String `tfsdk:"name"`
+	Privacy     types.String `tfsdk:"privacy"`
+	MemberCount types.Int64  `tfsdk:"member_count"`
+}
+
+func newTeamsDatasource() datasource.DataSource {
+	return &teamsDatasource{}
+}
+
+func (t *teamsDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	t.client = req.ProviderData.(*Client)
+}
+
+func (*teamsDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_teams"
+}
+
+func (*teamsDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: heredoc.Doc(`
+			Use this data source to list every team in the organization, for example to audit team access.
+
+			More info in the Buildkite [documentation](https://buildkite.com/docs/platform/team-management).
+		`),
+		Attributes: map[string]schema.Attribute{
+			"teams": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The teams in the organization, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The GraphQL ID of the team.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the team.",
+						},
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The slug of the team.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the team.",
+						},
+						"privacy": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The privacy setting of the team.",
+						},
+						"member_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of members in the team.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *teamsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state teamsDatasourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teams, err := t.client.ListTeams(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read teams",
+			fmt.Sprintf("Unable to read teams: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Teams = make([]teamsTeamModel, len(teams))
+	for i, team := range teams {
+		state.Teams[i] = teamsTeamModel{
+			ID:          types.StringValue(team.ID),
+			UUID:        types.StringValue(team.UUID),
+			Slug:        types.StringValue(team.Slug),
+			Name:        types.StringValue(team.Name),
+			Privacy:     types.StringValue(team.Privacy),
+			MemberCount: types.Int64Value(int64(team.MemberCount)),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ListTeams returns every team in the organization, ordered by name
+func (client *Client) ListTeams(ctx context.Context) ([]Team, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]Team, pageInfo, error) {
+		r, err := listTeams(ctx, client.genqlient, client.organization, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var teams []Team
+		for _, edge := range r.Organization.Teams.Edges {
+			team := newTeam(edge.Node.TeamFields)
+			team.MemberCount = edge.Node.Members.Count
+			teams = append(teams, team)
+		}
+
+		return teams, &r.Organization.Teams.PageInfo, nil
+	})
+}
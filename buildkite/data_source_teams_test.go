@@ -0,0 +1,72 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBuildkiteTeamsDatasource(t *testing.T) {
+	t.Run("lists teams including a new team", func(t *testing.T) {
+		name := acctest.RandString(12)
+
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			CheckDestroy:             testAccCheckTeamResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+						resource "buildkite_team" "team" {
+							name = "%s"
+							default_team = false
+							privacy = "VISIBLE"
+							default_member_role = "MEMBER"
+						}
+
+						data "buildkite_teams" "all" {
+							depends_on = [buildkite_team.team]
+						}
+					`, name),
+					Check: resource.TestCheckTypeSetElemNestedAttrs("data.buildkite_teams.all", "teams.*", map[string]string{
+						"name":    name,
+						"privacy": "VISIBLE",
+					}),
+				},
+			},
+		})
+	})
+}
+
+func TestListTeams(t *testing.T) {
+	t.Parallel()
+
+	var page int
+	client := newTestGraphqlClient(t, func(operation string) string {
+		page++
+		if page == 1 {
+			return `{"data": {"organization": {"teams": {
+				"pageInfo": {"endCursor": "first", "hasNextPage": true},
+				"edges": [{"node": {"id": "1", "slug": "alpha", "privacy": "VISIBLE", "members": {"count": 3}}}]
+			}}}}`
+		}
+		return `{"data": {"organization": {"teams": {
+			"pageInfo": {"endCursor": "second", "hasNextPage": false},
+			"edges": [{"node": {"id": "2", "slug": "beta", "privacy": "SECRET", "members": {"count": 0}}}]
+		}}}}`
+	})
+
+	teams, err := client.ListTeams(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(teams) != 2 {
+		t.Fatalf("expected teams from both pages, got %d", len(teams))
+	}
+	if teams[0].MemberCount != 3 || teams[1].Privacy != "SECRET" {
+		t.Errorf("unexpected teams: %+v", teams)
+	}
+}
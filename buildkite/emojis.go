@@ -0,0 +1,33 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Emoji is an emoji available to an organization, either built in or custom
+type Emoji struct {
+	Name    string   `json:"name"`
+	URL     string   `json:"url"`
+	Aliases []string `json:"aliases"`
+}
+
+// ListEmojis returns every emoji available to the organization, including its custom emojis
+func (client *Client) ListEmojis(ctx context.Context) ([]Emoji, error) {
+	var emojis []Emoji
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/organizations/%s/emojis", client.organization), nil, &emojis)
+		return retryContextError(ctx, err)
+	})
+
+	return emojis, err
+}
@@ -0,0 +1,26 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestListEmojis(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/organizations/test-org/emojis" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"name": "rocket", "url": "https://example.com/rocket.png", "aliases": ["launch"]}]`))
+	})
+
+	emojis, err := client.ListEmojis(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(emojis) != 1 || emojis[0].URL != "https://example.com/rocket.png" {
+		t.Errorf("unexpected emojis: %+v", emojis)
+	}
+}
@@ -0,0 +1,204 @@
+package buildkite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	genqlient "github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// partialResultClient lets GraphQL responses containing both data and errors succeed, as long as every top level field
+// in the data was resolved. The errors are logged instead, so a failure resolving one nested field doesn't discard the
+// rest of the response.
+type partialResultClient struct {
+	next genqlient.Client
+}
+
+func newPartialResultClient(next genqlient.Client) *partialResultClient {
+	return &partialResultClient{next: next}
+}
+
+func (c *partialResultClient) MakeRequest(ctx context.Context, req *genqlient.Request, resp *genqlient.Response) error {
+	var data json.RawMessage
+	raw := &genqlient.Response{Data: &data}
+
+	err := c.next.MakeRequest(ctx, req, raw)
+	resp.Extensions = raw.Extensions
+	resp.Errors = raw.Errors
+	if err != nil && (len(raw.Errors) == 0 || !isPartialResult(data) || isStrictGraphQL(ctx)) {
+		return err
+	}
+
+	if len(raw.Errors) > 0 {
+		tflog.Warn(ctx, "GraphQL response contained errors, using the partial result", map[string]interface{}{
+			"operation": req.OpName,
+			"errors":    raw.Errors.Error(),
+		})
+	}
+
+	if resp.Data == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, resp.Data)
+}
+
+type strictGraphQLKey struct{}
+
+// withStrictGraphQL returns a context whose GraphQL requests fail on any error in the response, rather than using a
+// partial result
+func withStrictGraphQL(ctx context.Context) context.Context {
+	return context.WithValue(ctx, strictGraphQLKey{}, true)
+}
+
+func isStrictGraphQL(ctx context.Context) bool {
+	strict, _ := ctx.Value(strictGraphQLKey{}).(bool)
+	return strict
+}
+
+// withOptionalFields runs full, which queries every field a data source can return, falling back to required when
+// full fails because a field isn't available to the organization, e.g. on its Buildkite plan. It reports whether the
+// fallback was used, in which case the fields only full asks for should be set to null. full is run with a strict
+// context, as an unavailable field is usually resolved as null alongside the error, which would otherwise be used as
+// a partial result. With strictGraphQL set there's no fallback.
+func (client *Client) withOptionalFields(ctx context.Context, full, required func(ctx context.Context) error) (bool, error) {
+	err := full(withStrictGraphQL(ctx))
+	if err == nil || client.strictGraphQL || !isFieldUnavailableError(err) {
+		return false, err
+	}
+
+	tflog.Warn(ctx, "GraphQL fields aren't available to the organization, querying without them", map[string]interface{}{
+		"error": err.Error(),
+	})
+	return true, required(ctx)
+}
+
+// isFieldUnavailableError reports whether a GraphQL query failed because it asked for a field the organization can't
+// use, or that the API doesn't have
+func isFieldUnavailableError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, phrase := range []string{"not available", "isn't available", "upgrade your plan", "doesn't exist on type"} {
+		if strings.Contains(message, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPartialResult reports whether the data of a response with errors is still usable, which is when every top level
+// field has a value
+func isPartialResult(data json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) == 0 {
+		return false
+	}
+	for _, value := range fields {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	// defaultGraphQLPacingThreshold is the fraction of the GraphQL rate limit left below which paginated reads slow down
+	defaultGraphQLPacingThreshold = 0.1
+	// defaultGraphQLPacingMaxDelay is the longest a paginated read waits between pages while the limit is running out
+	defaultGraphQLPacingMaxDelay = 5 * time.Second
+)
+
+// graphqlCostRecorder remembers the rate limit status reported in the headers of the latest GraphQL response. Each
+// query's complexity counts towards the limit, so the remaining budget shows how close the provider is to being
+// throttled.
+type graphqlCostRecorder struct {
+	next genqlient.Doer
+
+	mu   sync.Mutex
+	last RateLimitInfo
+}
+
+func newGraphqlCostRecorder(next genqlient.Doer) *graphqlCostRecorder {
+	return &graphqlCostRecorder{next: next}
+}
+
+func (r *graphqlCostRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if info, err := parseRateLimit(resp.Header, time.Now()); err == nil && info.Limited {
+		r.mu.Lock()
+		r.last = info
+		r.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// graphqlPacing decides how long to wait before fetching the next page of a GraphQL connection
+type graphqlPacing struct {
+	// threshold is the fraction of the limit left below which pages are delayed. Zero disables pacing
+	threshold float64
+	maxDelay  time.Duration
+}
+
+// delay grows from nothing at the threshold to maxDelay as the remaining budget runs out, but never waits past the
+// point the limit resets
+func (p graphqlPacing) delay(cost RateLimitInfo, now time.Time) time.Duration {
+	if p.threshold <= 0 || !cost.Limited || cost.Limit <= 0 {
+		return 0
+	}
+
+	threshold := p.threshold * float64(cost.Limit)
+	if float64(cost.Remaining) >= threshold {
+		return 0
+	}
+
+	delay := time.Duration(float64(p.maxDelay) * (1 - float64(cost.Remaining)/threshold))
+	if untilReset := cost.ResetAt.Sub(now); delay > untilReset {
+		delay = untilReset
+	}
+	return delay
+}
+
+// LastGraphQLCost returns the GraphQL rate limit status reported by the most recent GraphQL response. Limited is false
+// until a response with rate limit headers has been received.
+func (client *Client) LastGraphQLCost() RateLimitInfo {
+	if client.graphqlCost == nil {
+		return RateLimitInfo{}
+	}
+
+	client.graphqlCost.mu.Lock()
+	defer client.graphqlCost.mu.Unlock()
+	return client.graphqlCost.last
+}
+
+// paceGraphQL waits before the next page of a paginated read when the GraphQL rate limit is running low, so large
+// reads slow down instead of being throttled
+func (client *Client) paceGraphQL(ctx context.Context) error {
+	cost := client.LastGraphQLCost()
+	delay := client.graphqlPacing.delay(cost, time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, "GraphQL rate limit is running low, waiting before the next page", map[string]interface{}{
+		"remaining": cost.Remaining,
+		"limit":     cost.Limit,
+		"delay":     delay.String(),
+	})
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
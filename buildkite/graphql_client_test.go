@@ -0,0 +1,225 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	genqlient "github.com/Khan/genqlient/graphql"
+)
+
+func TestPartialResultClient(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		response  string
+		expectErr bool
+	}{
+		"partial data is used": {
+			response:  `{"data": {"team": {"id": "VGVhbQ==", "slug": "platform"}}, "errors": [{"message": "members could not be loaded", "path": ["team", "members"]}]}`,
+			expectErr: false,
+		},
+		"null top level field fails": {
+			response:  `{"data": {"team": null}, "errors": [{"message": "not authorized", "path": ["team"]}]}`,
+			expectErr: true,
+		},
+		"missing data fails": {
+			response:  `{"errors": [{"message": "Field 'nope' doesn't exist on type 'Query'"}]}`,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tc.response))
+			}))
+			t.Cleanup(server.Close)
+
+			client := newPartialResultClient(genqlient.NewClient(server.URL, server.Client()))
+
+			var data struct {
+				Team *struct {
+					Slug string `json:"slug"`
+				} `json:"team"`
+			}
+			err := client.MakeRequest(context.Background(), &genqlient.Request{Query: "query { team { slug } }"}, &genqlient.Response{Data: &data})
+			if tc.expectErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if data.Team == nil || data.Team.Slug != "platform" {
+				t.Errorf("expected the partial data to be decoded, got %+v", data.Team)
+			}
+		})
+	}
+}
+
+func TestGraphqlPacingDelay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	pacing := graphqlPacing{threshold: 0.1, maxDelay: 4 * time.Second}
+
+	testCases := map[string]struct {
+		pacing   graphqlPacing
+		cost     RateLimitInfo
+		expected time.Duration
+	}{
+		"no rate limit reported": {pacing: pacing, cost: RateLimitInfo{}, expected: 0},
+		"plenty left":            {pacing: pacing, cost: RateLimitInfo{Limited: true, Limit: 1000, Remaining: 500, ResetAt: now.Add(time.Minute)}, expected: 0},
+		"half the threshold":     {pacing: pacing, cost: RateLimitInfo{Limited: true, Limit: 1000, Remaining: 50, ResetAt: now.Add(time.Minute)}, expected: 2 * time.Second},
+		"used up":                {pacing: pacing, cost: RateLimitInfo{Limited: true, Limit: 1000, Remaining: 0, ResetAt: now.Add(time.Minute)}, expected: 4 * time.Second},
+		"resets sooner":          {pacing: pacing, cost: RateLimitInfo{Limited: true, Limit: 1000, Remaining: 0, ResetAt: now.Add(time.Second)}, expected: time.Second},
+		"disabled":               {pacing: graphqlPacing{}, cost: RateLimitInfo{Limited: true, Limit: 1000, Remaining: 0, ResetAt: now.Add(time.Minute)}, expected: 0},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if delay := tc.pacing.delay(tc.cost, now); delay != tc.expected {
+				t.Errorf("expected a delay of %s, got %s", tc.expected, delay)
+			}
+		})
+	}
+}
+
+func TestPaceGraphQL(t *testing.T) {
+	t.Parallel()
+
+	var page int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-Limit", "1000")
+		w.Header().Set("RateLimit-Remaining", "0")
+		w.Header().Set("RateLimit-Reset", "60")
+		if page == 1 {
+			w.Write([]byte(`{"data": {"organization": {"teams": {
+				"pageInfo": {"endCursor": "first", "hasNextPage": true},
+				"edges": [{"node": {"id": "1", "slug": "alpha", "members": {"count": 1}}}]
+			}}}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"organization": {"teams": {
+			"pageInfo": {"endCursor": "second", "hasNextPage": false},
+			"edges": [{"node": {"id": "2", "slug": "beta", "members": {"count": 1}}}]
+		}}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cost := newGraphqlCostRecorder(server.Client())
+	client := &Client{
+		genqlient:     genqlient.NewClient(server.URL, cost),
+		graphqlCost:   cost,
+		graphqlPacing: graphqlPacing{threshold: 0.1, maxDelay: 50 * time.Millisecond},
+	}
+
+	start := time.Now()
+	teams, err := client.ListTeams(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(teams) != 2 {
+		t.Fatalf("expected teams from both pages, got %d", len(teams))
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the second page to be delayed, took %s", elapsed)
+	}
+
+	if last := client.LastGraphQLCost(); !last.Limited || last.Limit != 1000 || last.Remaining != 0 {
+		t.Errorf("unexpected GraphQL cost: %+v", last)
+	}
+}
+
+func TestWithOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	respond := func(operation string) string {
+		if operation == "getOrganization" {
+			return `{"errors": [{"message": "The IP allowlist is not available on your plan", "path": ["organization", "allowedApiIpAddresses"]}]}`
+		}
+		return `{"data": {"organization": {"id": "T3JnYW5pemF0aW9uLS0tMQ==", "uuid": "org-uuid"}}}`
+	}
+
+	read := func(client *Client) (*getOrganizationRequiredFieldsResponse, bool, error) {
+		var required *getOrganizationRequiredFieldsResponse
+		fellBack, err := client.withOptionalFields(context.Background(), func(ctx context.Context) error {
+			_, err := getOrganization(ctx, client.genqlient, client.organization)
+			return err
+		}, func(ctx context.Context) error {
+			var err error
+			required, err = getOrganizationRequiredFields(ctx, client.genqlient, client.organization)
+			return err
+		})
+		return required, fellBack, err
+	}
+
+	t.Run("falls back to the required fields", func(t *testing.T) {
+		t.Parallel()
+
+		required, fellBack, err := read(newTestGraphqlClient(t, respond))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !fellBack || required == nil || required.Organization.Uuid != "org-uuid" {
+			t.Errorf("expected to fall back to the required fields, got %v and %+v", fellBack, required)
+		}
+	})
+
+	t.Run("falls back when the unavailable field is resolved as null", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string {
+			if operation == "getOrganization" {
+				return `{
+					"data": {"organization": {"id": "T3JnYW5pemF0aW9uLS0tMQ==", "uuid": "org-uuid", "allowedApiIpAddresses": null}},
+					"errors": [{"message": "The IP allowlist is not available on your plan", "path": ["organization", "allowedApiIpAddresses"]}]
+				}`
+			}
+			return respond(operation)
+		})
+
+		required, fellBack, err := read(client)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !fellBack || required == nil || required.Organization.Uuid != "org-uuid" {
+			t.Errorf("expected to fall back to the required fields, got %v and %+v", fellBack, required)
+		}
+	})
+
+	t.Run("doesn't fall back with strict GraphQL", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, respond)
+		client.strictGraphQL = true
+		if _, fellBack, err := read(client); err == nil || fellBack {
+			t.Errorf("expected the first query's error, got %v", err)
+		}
+	})
+
+	t.Run("doesn't fall back for other errors", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"errors": [{"message": "Not authorized"}]}`
+		})
+		if _, fellBack, err := read(client); err == nil || fellBack {
+			t.Errorf("expected the first query's error, got %v", err)
+		}
+	})
+}
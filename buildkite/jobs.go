@@ -0,0 +1,90 @@
+package buildkite
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// JobState is the state of a job. The REST API reports states in lower case and GraphQL in upper case, both are parsed
+// into the same values.
+type JobState string
+
+const (
+	JobStatePending         JobState = "PENDING"
+	JobStateWaiting         JobState = "WAITING"
+	JobStateWaitingFailed   JobState = "WAITING_FAILED"
+	JobStateBlocked         JobState = "BLOCKED"
+	JobStateBlockedFailed   JobState = "BLOCKED_FAILED"
+	JobStateUnblocked       JobState = "UNBLOCKED"
+	JobStateUnblockedFailed JobState = "UNBLOCKED_FAILED"
+	JobStateLimiting        JobState = "LIMITING"
+	JobStateLimited         JobState = "LIMITED"
+	JobStateScheduled       JobState = "SCHEDULED"
+	JobStateAssigned        JobState = "ASSIGNED"
+	JobStateAccepted        JobState = "ACCEPTED"
+	JobStateRunning         JobState = "RUNNING"
+	// JobStatePassed and JobStateFailed are only reported by the REST API, GraphQL reports both as JobStateFinished
+	JobStatePassed    JobState = "PASSED"
+	JobStateFailed    JobState = "FAILED"
+	JobStateFinished  JobState = "FINISHED"
+	JobStateCanceling JobState = "CANCELING"
+	JobStateCanceled  JobState = "CANCELED"
+	JobStateTimingOut JobState = "TIMING_OUT"
+	JobStateTimedOut  JobState = "TIMED_OUT"
+	JobStateSkipped   JobState = "SKIPPED"
+	JobStateBroken    JobState = "BROKEN"
+	JobStateExpired   JobState = "EXPIRED"
+	JobStateUnknown   JobState = "UNKNOWN"
+)
+
+// jobStates maps every known state to whether it is terminal
+var jobStates = map[JobState]bool{
+	JobStatePending:         false,
+	JobStateWaiting:         false,
+	JobStateWaitingFailed:   true,
+	JobStateBlocked:         false,
+	JobStateBlockedFailed:   true,
+	JobStateUnblocked:       true,
+	JobStateUnblockedFailed: true,
+	JobStateLimiting:        false,
+	JobStateLimited:         false,
+	JobStateScheduled:       false,
+	JobStateAssigned:        false,
+	JobStateAccepted:        false,
+	JobStateRunning:         false,
+	JobStatePassed:          true,
+	JobStateFailed:          true,
+	JobStateFinished:        true,
+	JobStateCanceling:       false,
+	JobStateCanceled:        true,
+	JobStateTimingOut:       false,
+	JobStateTimedOut:        true,
+	JobStateSkipped:         true,
+	JobStateBroken:          true,
+	JobStateExpired:         true,
+}
+
+// ParseJobState converts a job state from either API into a JobState. States the provider doesn't know about are
+// returned as JobStateUnknown so new states added by Buildkite don't cause errors.
+func ParseJobState(state string) JobState {
+	s := JobState(strings.ToUpper(state))
+	if _, ok := jobStates[s]; !ok {
+		return JobStateUnknown
+	}
+	return s
+}
+
+// IsTerminal reports whether a job in this state has stopped and won't change state again
+func (s JobState) IsTerminal() bool {
+	return jobStates[s]
+}
+
+// UnmarshalJSON parses a job state string with ParseJobState
+func (s *JobState) UnmarshalJSON(data []byte) error {
+	var state string
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	*s = ParseJobState(state)
+	return nil
+}
@@ -0,0 +1,52 @@
+package buildkite
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseJobState(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]JobState{
+		"passed":        JobStatePassed,
+		"FINISHED":      JobStateFinished,
+		"timed_out":     JobStateTimedOut,
+		"BLOCKED":       JobStateBlocked,
+		"something_new": JobStateUnknown,
+		"":              JobStateUnknown,
+	}
+
+	for input, expected := range testCases {
+		if got := ParseJobState(input); got != expected {
+			t.Errorf("expected %q to parse as %s, got %s", input, expected, got)
+		}
+	}
+}
+
+func TestJobStateIsTerminal(t *testing.T) {
+	t.Parallel()
+
+	for _, state := range []JobState{JobStatePassed, JobStateFailed, JobStateCanceled, JobStateExpired} {
+		if !state.IsTerminal() {
+			t.Errorf("expected %s to be terminal", state)
+		}
+	}
+	for _, state := range []JobState{JobStateScheduled, JobStateRunning, JobStateCanceling, JobStateUnknown} {
+		if state.IsTerminal() {
+			t.Errorf("expected %s not to be terminal", state)
+		}
+	}
+}
+
+func TestJobStateUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var job BuildJob
+	if err := json.Unmarshal([]byte(`{"id": "abc", "state": "running"}`), &job); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if job.State != JobStateRunning {
+		t.Errorf("expected running, got %s", job.State)
+	}
+}
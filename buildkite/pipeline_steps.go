@@ -0,0 +1,181 @@
+package buildkite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PipelineSteps builds a pipeline's steps in code, as an alternative to writing the YAML by hand. Render checks the
+// steps for mistakes Buildkite would otherwise only report when a build is created.
+type PipelineSteps struct {
+	// Env is set on every step of the pipeline
+	Env   map[string]string `yaml:"env,omitempty"`
+	Steps []PipelineStep    `yaml:"steps"`
+}
+
+// PipelineStep is a step of a pipeline, one of CommandStep, WaitStep, BlockStep or TriggerStep
+type PipelineStep interface {
+	validate() error
+	stepKey() string
+	stepDependsOn() []string
+}
+
+// CommandStep runs commands on an agent
+type CommandStep struct {
+	Label     string            `yaml:"label,omitempty"`
+	Key       string            `yaml:"key,omitempty"`
+	Commands  []string          `yaml:"commands"`
+	Env       map[string]string `yaml:"env,omitempty"`
+	Agents    map[string]string `yaml:"agents,omitempty"`
+	DependsOn []string          `yaml:"depends_on,omitempty"`
+	// Plugins are given as plugin name to configuration, e.g. {"docker#v5.9.0": {"image": "golang"}}
+	Plugins          []map[string]interface{} `yaml:"plugins,omitempty"`
+	Parallelism      int                      `yaml:"parallelism,omitempty"`
+	TimeoutInMinutes int                      `yaml:"timeout_in_minutes,omitempty"`
+	// Concurrency limits how many jobs in ConcurrencyGroup run at once, across every build of every pipeline. The two
+	// are set together, e.g. to only run one deploy at a time.
+	Concurrency      int    `yaml:"concurrency,omitempty"`
+	ConcurrencyGroup string `yaml:"concurrency_group,omitempty"`
+}
+
+// WaitStep waits for all previous steps to pass before continuing
+type WaitStep struct {
+	Key               string   `yaml:"key,omitempty"`
+	DependsOn         []string `yaml:"depends_on,omitempty"`
+	ContinueOnFailure bool     `yaml:"continue_on_failure,omitempty"`
+}
+
+// BlockStep pauses the build until it's unblocked, e.g. to approve a deploy
+type BlockStep struct {
+	Label     string   `yaml:"block"`
+	Key       string   `yaml:"key,omitempty"`
+	Prompt    string   `yaml:"prompt,omitempty"`
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// TriggerStep creates a build of another pipeline
+type TriggerStep struct {
+	// Pipeline is the slug of the pipeline to trigger
+	Pipeline  string        `yaml:"trigger"`
+	Label     string        `yaml:"label,omitempty"`
+	Key       string        `yaml:"key,omitempty"`
+	Async     bool          `yaml:"async,omitempty"`
+	Build     *TriggerBuild `yaml:"build,omitempty"`
+	DependsOn []string      `yaml:"depends_on,omitempty"`
+}
+
+// TriggerBuild is the build created by a TriggerStep
+type TriggerBuild struct {
+	Branch  string            `yaml:"branch,omitempty"`
+	Commit  string            `yaml:"commit,omitempty"`
+	Message string            `yaml:"message,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+}
+
+// Add appends steps to the pipeline, returning it so calls can be chained
+func (p *PipelineSteps) Add(steps ...PipelineStep) *PipelineSteps {
+	p.Steps = append(p.Steps, steps...)
+	return p
+}
+
+// Render validates the steps and returns them as YAML that can be used as a pipeline's steps
+func (p *PipelineSteps) Render() (string, error) {
+	if len(p.Steps) == 0 {
+		return "", errors.New("pipeline has no steps")
+	}
+
+	keys := map[string]bool{}
+	for i, step := range p.Steps {
+		if err := step.validate(); err != nil {
+			return "", fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if key := step.stepKey(); key != "" {
+			if keys[key] {
+				return "", fmt.Errorf("step %d: key %q is used by more than one step", i+1, key)
+			}
+			keys[key] = true
+		}
+	}
+	// checked separately, so a step can depend on one defined after it
+	for i, step := range p.Steps {
+		for _, key := range step.stepDependsOn() {
+			if !keys[key] {
+				return "", fmt.Errorf("step %d: depends on unknown step %q", i+1, key)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (s CommandStep) validate() error {
+	if len(s.Commands) == 0 {
+		return errors.New("command step has no commands")
+	}
+	if s.Parallelism < 0 {
+		return fmt.Errorf("parallelism must not be negative, got %d", s.Parallelism)
+	}
+	if s.TimeoutInMinutes < 0 {
+		return fmt.Errorf("timeout must be a positive number of minutes, got %d", s.TimeoutInMinutes)
+	}
+	if s.Concurrency < 0 {
+		return fmt.Errorf("concurrency must not be negative, got %d", s.Concurrency)
+	}
+	if (s.Concurrency > 0) != (s.ConcurrencyGroup != "") {
+		return errors.New("concurrency and concurrency group must be set together")
+	}
+	return nil
+}
+
+func (s CommandStep) stepKey() string         { return s.Key }
+func (s CommandStep) stepDependsOn() []string { return s.DependsOn }
+
+func (WaitStep) validate() error           { return nil }
+func (s WaitStep) stepKey() string         { return s.Key }
+func (s WaitStep) stepDependsOn() []string { return s.DependsOn }
+
+// MarshalYAML renders a wait step without options as the plain "wait" string, as it's usually written
+func (s WaitStep) MarshalYAML() (interface{}, error) {
+	if s.Key == "" && len(s.DependsOn) == 0 && !s.ContinueOnFailure {
+		return "wait", nil
+	}
+
+	// a distinct type, so this method isn't called again when the fields are encoded
+	type waitStep WaitStep
+	return struct {
+		Wait     *string `yaml:"wait"`
+		waitStep `yaml:",inline"`
+	}{waitStep: waitStep(s)}, nil
+}
+
+func (s BlockStep) validate() error {
+	if s.Label == "" {
+		return errors.New("block step has no label")
+	}
+	return nil
+}
+
+func (s BlockStep) stepKey() string         { return s.Key }
+func (s BlockStep) stepDependsOn() []string { return s.DependsOn }
+
+func (s TriggerStep) validate() error {
+	if s.Pipeline == "" {
+		return errors.New("trigger step has no pipeline")
+	}
+	return nil
+}
+
+func (s TriggerStep) stepKey() string         { return s.Key }
+func (s TriggerStep) stepDependsOn() []string { return s.DependsOn }
@@ -0,0 +1,92 @@
+package buildkite
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineStepsRender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renders each kind of step", func(t *testing.T) {
+		steps := &PipelineSteps{Env: map[string]string{"CI": "true"}}
+		steps.Add(
+			CommandStep{Label: "Test", Key: "test", Commands: []string{"make test"}, Parallelism: 2},
+			WaitStep{},
+			BlockStep{Label: "Deploy?", Key: "approve"},
+			WaitStep{DependsOn: []string{"approve"}, ContinueOnFailure: true},
+			TriggerStep{Pipeline: "deploy", Async: true, Build: &TriggerBuild{Branch: "main"}},
+			CommandStep{Label: "Deploy", Commands: []string{"make deploy"}, Concurrency: 1, ConcurrencyGroup: "deploy/production"},
+		)
+
+		rendered, err := steps.Render()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := strings.Join([]string{
+			"env:",
+			"  CI: \"true\"",
+			"steps:",
+			"  - label: Test",
+			"    key: test",
+			"    commands:",
+			"      - make test",
+			"    parallelism: 2",
+			"  - wait",
+			"  - block: Deploy?",
+			"    key: approve",
+			"  - wait: null",
+			"    depends_on:",
+			"      - approve",
+			"    continue_on_failure: true",
+			"  - trigger: deploy",
+			"    async: true",
+			"    build:",
+			"      branch: main",
+			"  - label: Deploy",
+			"    commands:",
+			"      - make deploy",
+			"    concurrency: 1",
+			"    concurrency_group: deploy/production",
+			"",
+		}, "\n")
+		if rendered != expected {
+			t.Errorf("unexpected YAML:\n%s", rendered)
+		}
+	})
+
+	testCases := map[string]struct {
+		steps    []PipelineStep
+		expected string
+	}{
+		"no steps":    {steps: nil, expected: "no steps"},
+		"no commands": {steps: []PipelineStep{CommandStep{Label: "Test"}}, expected: "step 1: command step has no commands"},
+		"concurrency without a group": {
+			steps:    []PipelineStep{CommandStep{Commands: []string{"true"}, Concurrency: 1}},
+			expected: "concurrency and concurrency group must be set together",
+		},
+		"unlabelled block":    {steps: []PipelineStep{BlockStep{}}, expected: "block step has no label"},
+		"trigger no pipeline": {steps: []PipelineStep{TriggerStep{Label: "Deploy"}}, expected: "trigger step has no pipeline"},
+		"duplicate key": {
+			steps:    []PipelineStep{CommandStep{Key: "a", Commands: []string{"true"}}, BlockStep{Label: "Go", Key: "a"}},
+			expected: `step 2: key "a" is used by more than one step`,
+		},
+		"unknown dependency": {
+			steps:    []PipelineStep{CommandStep{Commands: []string{"true"}, DependsOn: []string{"build"}}},
+			expected: `depends on unknown step "build"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := (&PipelineSteps{Steps: tc.steps}).Render()
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -40,7 +40,9 @@ type providerModel struct {
 	ApiToken                types.String   `tfsdk:"api_token"`
 	ArchivePipelineOnDelete types.Bool     `tfsdk:"archive_pipeline_on_delete"`
 	GraphqlUrl              types.String   `tfsdk:"graphql_url"`
+	ManagedBy               types.String   `tfsdk:"managed_by"`
 	Organization            types.String   `tfsdk:"organization"`
+	PublicIPURL             types.String   `tfsdk:"public_ip_url"`
 	RestUrl                 types.String   `tfsdk:"rest_url"`
 	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
@@ -80,6 +82,18 @@ func (tf *terraformProvider) Configure(ctx context.Context, req provider.Configu
 		timeouts:   data.Timeouts,
 		userAgent:  userAgent("buildkite", tf.version, req.TerraformVersion),
 	}
+	if !data.ManagedBy.IsNull() {
+		config.managedBy = data.ManagedBy.ValueString()
+		config.disableManagedByTag = config.managedBy == ""
+	}
+	// unlike the API URLs, an empty string is meaningful here: it turns the lookup off
+	if !data.PublicIPURL.IsNull() {
+		config.publicIPURL = data.PublicIPURL.ValueString()
+		config.disablePublicIPLookup = config.publicIPURL == ""
+	} else if v, ok := os.LookupEnv("BUILDKITE_PUBLIC_IP_URL"); ok {
+		config.publicIPURL = v
+		config.disablePublicIPLookup = v == ""
+	}
 	client, err := NewClient(&config)
 
 	if err != nil {
@@ -112,11 +126,27 @@ func userAgent(providerName, providerVersion, tfVersion string) string {
 
 func (*terraformProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		newAgentJobsDatasource,
+		newAgentTokensDatasource,
+		newBuildEnvDatasource,
+		newBuildTimelineDatasource,
+		newBuildsDatasource,
 		newClusterDatasource,
+		newClustersDatasource,
+		newGraphqlDatasource,
 		newMetaDatasource,
 		newOrganizationDatasource,
+		newOrganizationFeaturesDatasource,
+		newOrganizationUsageDatasource,
 		newPipelineDatasource,
+		newPipelineBadgeDatasource,
+		newPipelineExportDatasource,
+		newPipelineIntegrationDatasource,
+		newQueueMetricsDatasource,
+		newRateLimitDatasource,
 		newTeamDatasource,
+		newTeamMembersDatasource,
+		newTeamsDatasource,
 		newSignedPipelineStepsDataSource,
 	}
 }
@@ -132,6 +162,8 @@ func (tf *terraformProvider) Resources(context.Context) []func() resource.Resour
 		newClusterAgentTokenResource,
 		newClusterQueueResource,
 		newClusterResource,
+		newClusterSecretResource,
+		newClusterTeamResource,
 		newDefaultQueueClusterResource,
 		newOrganizationBannerResource,
 		newOrganizationResource,
@@ -139,6 +171,7 @@ func (tf *terraformProvider) Resources(context.Context) []func() resource.Resour
 		newPipelineTeamResource,
 		newPipelineTemplateResource,
 		newPipelineResource(&tf.archivePipelineOnDelete),
+		newRegistryTokenResource,
 		newTeamMemberResource,
 		newTeamResource,
 		newTestSuiteResource,
@@ -169,6 +202,17 @@ func (*terraformProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 				MarkdownDescription: "Base URL for the REST API to use. If not provided, the value is taken from the `BUILDKITE_REST_URL` environment variable.",
 			},
+			"managed_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The value of the `managed_by` tag added to pipelines the provider manages, so they can be told apart " +
+					"from pipelines created in the UI. Defaults to `" + defaultManagedBy + "`. Set to an empty string to stop pipelines being tagged.",
+			},
+			"public_ip_url": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "URL returning the public IP address Terraform runs from as plain text, used to warn when a change to " +
+					"`buildkite_organization`'s `allowed_api_ip_addresses` would exclude it. Defaults to `" + defaultPublicIPEndpoint + "`. " +
+					"Set to an empty string to turn the lookup off, e.g. on runners without internet access. If not provided, the value is taken from the `BUILDKITE_PUBLIC_IP_URL` environment variable.",
+			},
 			"archive_pipeline_on_delete": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Enable this to archive pipelines when destroying the resource. This is opposed to completely deleting pipelines.",
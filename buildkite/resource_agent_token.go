@@ -46,6 +46,9 @@ func (at *agentTokenResource) Configure(ctx context.Context, req resource.Config
 }
 
 func (at *agentTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := at.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state agentTokenStateModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -71,7 +74,7 @@ func (at *agentTokenResource) Create(ctx context.Context, req resource.CreateReq
 			plan.Description.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -91,6 +94,9 @@ func (at *agentTokenResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (at *agentTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := at.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state agentTokenStateModel
 
 	diags := req.State.Get(ctx, &state)
@@ -114,7 +120,7 @@ func (at *agentTokenResource) Delete(ctx context.Context, req resource.DeleteReq
 			"Revoked by Terraform",
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -131,6 +137,9 @@ func (agentTokenResource) Metadata(ctx context.Context, req resource.MetadataReq
 }
 
 func (at *agentTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := at.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var plan, state agentTokenStateModel
 
 	diags := req.State.Get(ctx, &plan)
@@ -155,7 +164,7 @@ func (at *agentTokenResource) Read(ctx context.Context, req resource.ReadRequest
 			fmt.Sprintf("%s/%s", at.client.organization, plan.Uuid.ValueString()),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -92,6 +92,9 @@ func (c *clusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 }
 
 func (c *clusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var state *clusterResourceModel
 
 	diags := req.Plan.Get(ctx, &state)
@@ -123,7 +126,7 @@ func (c *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 			state.Color.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -141,6 +144,9 @@ func (c *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (c *clusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state clusterResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -164,7 +170,7 @@ func (c *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		var err error
 		r, err = getNode(ctx, c.client.genqlient, state.ID.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -195,6 +201,9 @@ func (c *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (c *clusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state, plan clusterResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -212,6 +221,9 @@ func (c *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	// the cached cluster is out of date whether or not the update succeeds
+	c.client.clusters.Delete(state.Name.ValueString())
+
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		var err error
 		_, err = updateCluster(ctx,
@@ -224,7 +236,7 @@ func (c *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 			plan.Color.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -239,6 +251,9 @@ func (c *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (c *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state clusterResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -257,11 +272,13 @@ func (c *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	c.client.clusters.Delete(state.Name.ValueString())
+
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		var err error
 		_, err = deleteCluster(ctx, c.client.genqlient, c.client.organizationId, state.ID.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -285,3 +302,21 @@ func updateClusterResourceState(state *clusterResourceModel, res getNodeNodeClus
 	state.Emoji = types.StringPointerValue(res.Emoji)
 	state.Color = types.StringPointerValue(res.Color)
 }
+
+// GetClusterByName returns the cluster in the organization with the given name, or ErrNotFound if there isn't one.
+// Every cluster found while searching is cached by name for the life of the client, so resolving the clusters of many
+// queues, tokens or secrets only lists the organization's clusters once.
+func (client *Client) GetClusterByName(ctx context.Context, name string) (ClusterFields, error) {
+	if cluster, ok := client.clusters.Load(name); ok {
+		return cluster.(ClusterFields), nil
+	}
+
+	if _, err := client.ListClusters(ctx); err != nil {
+		return ClusterFields{}, err
+	}
+
+	if cluster, ok := client.clusters.Load(name); ok {
+		return cluster.(ClusterFields), nil
+	}
+	return ClusterFields{}, fmt.Errorf("cluster %s: %w", name, ErrNotFound)
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
@@ -25,6 +29,8 @@ type clusterAgentTokenResourceModel struct {
 	Token       types.String `tfsdk:"token"`
 	ClusterId   types.String `tfsdk:"cluster_id"`
 	ClusterUuid types.String `tfsdk:"cluster_uuid"`
+	// AllowedIpAddresses is a space separated string in the API
+	AllowedIpAddresses types.List `tfsdk:"allowed_ip_addresses"`
 }
 
 func newClusterAgentTokenResource() resource.Resource {
@@ -80,11 +86,25 @@ func (ct *clusterAgentToken) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"allowed_ip_addresses": resource_schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "A list of IP addresses in CIDR format that agents can use this token from. " +
+					"If not set, agents can use the token from any IP address.\n\n" +
+					"-> This feature isn't available to all organizations yet.",
+				// an empty list would be read back as null
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
 		},
 	}
 }
 
 func (ct *clusterAgentToken) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state clusterAgentTokenResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -111,9 +131,10 @@ func (ct *clusterAgentToken) Create(ctx context.Context, req resource.CreateRequ
 			ct.client.organizationId,
 			plan.ClusterId.ValueString(),
 			plan.Description.ValueString(),
+			joinAllowedIpAddresses(plan.AllowedIpAddresses, types.ListNull(types.StringType)),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -130,11 +151,16 @@ func (ct *clusterAgentToken) Create(ctx context.Context, req resource.CreateRequ
 	state.Token = types.StringValue(r.ClusterAgentTokenCreate.TokenValue)
 	state.ClusterId = types.StringValue(r.ClusterAgentTokenCreate.ClusterAgentToken.Cluster.Id)
 	state.ClusterUuid = types.StringValue(r.ClusterAgentTokenCreate.ClusterAgentToken.Cluster.Uuid)
+	state.AllowedIpAddresses, diags = splitAllowedIpAddresses(ctx, r.ClusterAgentTokenCreate.ClusterAgentToken.AllowedIpAddresses)
+	resp.Diagnostics.Append(diags...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (ct *clusterAgentToken) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state clusterAgentTokenResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -162,7 +188,7 @@ func (ct *clusterAgentToken) Read(ctx context.Context, req resource.ReadRequest,
 			state.ClusterUuid.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -177,6 +203,8 @@ func (ct *clusterAgentToken) Read(ctx context.Context, req resource.ReadRequest,
 		if edge.Node.Id == state.Id.ValueString() {
 			log.Printf("Found cluster Token with Description %s in cluster %s", edge.Node.Id, state.ClusterUuid.ValueString())
 			state.Description = types.StringValue(edge.Node.Description)
+			state.AllowedIpAddresses, diags = splitAllowedIpAddresses(ctx, edge.Node.AllowedIpAddresses)
+			resp.Diagnostics.Append(diags...)
 			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 			return
 		}
@@ -184,6 +212,9 @@ func (ct *clusterAgentToken) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (ct *clusterAgentToken) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state, plan clusterAgentTokenResourceModel
 
 	diagsState := req.State.Get(ctx, &state)
@@ -213,9 +244,10 @@ func (ct *clusterAgentToken) Update(ctx context.Context, req resource.UpdateRequ
 			ct.client.organizationId,
 			state.Id.ValueString(),
 			plan.Description.ValueString(),
+			joinAllowedIpAddresses(plan.AllowedIpAddresses, state.AllowedIpAddresses),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -226,12 +258,17 @@ func (ct *clusterAgentToken) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 	state.Description = types.StringValue(r.ClusterAgentTokenUpdate.ClusterAgentToken.Description)
+	state.AllowedIpAddresses, diags = splitAllowedIpAddresses(ctx, r.ClusterAgentTokenUpdate.ClusterAgentToken.AllowedIpAddresses)
+	resp.Diagnostics.Append(diags...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 
 }
 
 func (ct *clusterAgentToken) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var plan clusterAgentTokenResourceModel
 
 	diags := req.State.Get(ctx, &plan)
@@ -258,7 +295,7 @@ func (ct *clusterAgentToken) Delete(ctx context.Context, req resource.DeleteRequ
 			plan.Id.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -269,3 +306,28 @@ func (ct *clusterAgentToken) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 }
+
+// joinAllowedIpAddresses returns the planned allowed IP addresses as the API expects them. Nil leaves them out of the
+// request, so organizations without the feature can still manage tokens, unless they're being removed from a token
+// that had them.
+func joinAllowedIpAddresses(plan, state types.List) *string {
+	if plan.IsNull() || plan.IsUnknown() {
+		if state.IsNull() {
+			return nil
+		}
+		empty := ""
+		return &empty
+	}
+
+	cidrs := strings.Join(createCidrSliceFromList(plan), " ")
+	return &cidrs
+}
+
+// splitAllowedIpAddresses turns the API's space separated allowed IP addresses into a list, which is null when there
+// aren't any
+func splitAllowedIpAddresses(ctx context.Context, ips *string) (types.List, diag.Diagnostics) {
+	if ips == nil || strings.TrimSpace(*ips) == "" {
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValueFrom(ctx, types.StringType, strings.Fields(*ips))
+}
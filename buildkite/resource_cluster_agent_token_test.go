@@ -182,3 +182,30 @@ func testAccCheckClusterAgentTokenDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestClusterAgentTokenAllowedIpAddresses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cidrs, _ := types.ListValueFrom(ctx, types.StringType, []string{"10.0.0.0/8", "192.168.1.1/32"})
+	none := types.ListNull(types.StringType)
+
+	if joined := joinAllowedIpAddresses(cidrs, none); joined == nil || *joined != "10.0.0.0/8 192.168.1.1/32" {
+		t.Errorf("expected the addresses to be space separated, got %v", joined)
+	}
+	if joined := joinAllowedIpAddresses(none, none); joined != nil {
+		t.Errorf("expected no addresses to be left out of the request, got %q", *joined)
+	}
+	if joined := joinAllowedIpAddresses(none, cidrs); joined == nil || *joined != "" {
+		t.Errorf("expected removed addresses to be cleared, got %v", joined)
+	}
+
+	split, diags := splitAllowedIpAddresses(ctx, joinAllowedIpAddresses(cidrs, none))
+	if diags.HasError() || !split.Equal(cidrs) {
+		t.Errorf("expected %s, got %s", cidrs, split)
+	}
+	empty := " "
+	if split, _ := splitAllowedIpAddresses(ctx, &empty); !split.IsNull() {
+		t.Errorf("expected no addresses to be null, got %s", split)
+	}
+}
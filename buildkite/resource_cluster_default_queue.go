@@ -31,6 +31,9 @@ func newDefaultQueueClusterResource() resource.Resource {
 
 // Create implements resource.Resource.
 func (c *clusterDefaultQueueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan clusterDefaultQueueResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
@@ -51,7 +54,7 @@ func (c *clusterDefaultQueueResource) Create(ctx context.Context, req resource.C
 		var err error
 		r, err = setClusterDefaultQueue(ctx, c.client.genqlient, c.client.organizationId, plan.ClusterId.ValueString(), plan.QueueId.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -70,6 +73,9 @@ func (c *clusterDefaultQueueResource) Create(ctx context.Context, req resource.C
 
 // Delete implements resource.Resource.
 func (c *clusterDefaultQueueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state clusterDefaultQueueResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
@@ -88,7 +94,7 @@ func (c *clusterDefaultQueueResource) Delete(ctx context.Context, req resource.D
 		var err error
 		_, err = removeClusterDefaultQueue(ctx, c.client.genqlient, c.client.organizationId, state.ClusterId.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -115,6 +121,9 @@ func (c *clusterDefaultQueueResource) Configure(ctx context.Context, req resourc
 
 // Read implements resource.Resource.
 func (c *clusterDefaultQueueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state clusterDefaultQueueResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -136,7 +145,7 @@ func (c *clusterDefaultQueueResource) Read(ctx context.Context, req resource.Rea
 		var err error
 		r, err = getNode(ctx, c.client.genqlient, state.ID.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -208,6 +217,9 @@ func (c *clusterDefaultQueueResource) Schema(ctx context.Context, req resource.S
 
 // Update implements resource.Resource.
 func (c *clusterDefaultQueueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := c.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var plan clusterDefaultQueueResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
@@ -228,7 +240,7 @@ func (c *clusterDefaultQueueResource) Update(ctx context.Context, req resource.U
 		var err error
 		r, err = setClusterDefaultQueue(ctx, c.client.genqlient, c.client.organizationId, plan.ClusterId.ValueString(), plan.QueueId.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
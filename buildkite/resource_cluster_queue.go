@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,6 +23,7 @@ type clusterQueueResourceModel struct {
 	ClusterUuid types.String `tfsdk:"cluster_uuid"`
 	Key         types.String `tfsdk:"key"`
 	Description types.String `tfsdk:"description"`
+	Paused      types.Bool   `tfsdk:"paused"`
 }
 
 type clusterQueueResource struct {
@@ -84,11 +86,23 @@ func (clusterQueueResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            true,
 				MarkdownDescription: "A description for the cluster queue. ",
 			},
+			"paused": resource_schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Whether dispatching jobs to agents on this queue is paused. Jobs wait in the queue until it's resumed. " +
+					"If not set, the queue is left as it is, so it can be paused and resumed in the Buildkite UI.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
 func (cq *clusterQueueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := cq.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state clusterQueueResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -118,7 +132,7 @@ func (cq *clusterQueueResource) Create(ctx context.Context, req resource.CreateR
 			plan.Description.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -135,11 +149,27 @@ func (cq *clusterQueueResource) Create(ctx context.Context, req resource.CreateR
 	state.ClusterUuid = types.StringValue(r.ClusterQueueCreate.ClusterQueue.Cluster.Uuid)
 	state.Key = types.StringValue(r.ClusterQueueCreate.ClusterQueue.Key)
 	state.Description = types.StringPointerValue(r.ClusterQueueCreate.ClusterQueue.Description)
+	state.Paused = types.BoolValue(r.ClusterQueueCreate.ClusterQueue.DispatchPaused)
+
+	if plan.Paused.ValueBool() {
+		queue, err := cq.client.setQueueDispatchPaused(ctx, state.Id.ValueString(), true, terraformPauseNote)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to pause Cluster Queue",
+				fmt.Sprintf("Unable to pause Cluster Queue: %s", err.Error()),
+			)
+			return
+		}
+		state.Paused = types.BoolValue(queue.DispatchPaused)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (cq *clusterQueueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := cq.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state clusterQueueResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -166,7 +196,7 @@ func (cq *clusterQueueResource) Read(ctx context.Context, req resource.ReadReque
 			cq.client.organization, state.ClusterUuid.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -215,15 +245,21 @@ func (cq *clusterQueueResource) ImportState(ctx context.Context, req resource.Im
 }
 
 func (cq *clusterQueueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := cq.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state clusterQueueResourceModel
 	var description types.String
+	var paused types.Bool
 
 	diagsState := req.State.Get(ctx, &state)
 	diagsDescription := req.Plan.GetAttribute(ctx, path.Root("description"), &description)
+	diagsPaused := req.Plan.GetAttribute(ctx, path.Root("paused"), &paused)
 
 	//Load state and ontain description from plan (singularly)
 	resp.Diagnostics.Append(diagsState...)
 	resp.Diagnostics.Append(diagsDescription...)
+	resp.Diagnostics.Append(diagsPaused...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -248,7 +284,7 @@ func (cq *clusterQueueResource) Update(ctx context.Context, req resource.UpdateR
 			description.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -260,11 +296,28 @@ func (cq *clusterQueueResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	state.Description = types.StringPointerValue(r.ClusterQueueUpdate.ClusterQueue.Description)
+	state.Paused = types.BoolValue(r.ClusterQueueUpdate.ClusterQueue.DispatchPaused)
+
+	// an unset paused keeps the state's value, so a queue paused outside Terraform isn't resumed
+	if !paused.IsUnknown() && !paused.IsNull() && paused.ValueBool() != state.Paused.ValueBool() {
+		queue, err := cq.client.setQueueDispatchPaused(ctx, state.Id.ValueString(), paused.ValueBool(), terraformPauseNote)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update Cluster Queue dispatch",
+				fmt.Sprintf("Unable to update Cluster Queue dispatch: %s", err.Error()),
+			)
+			return
+		}
+		state.Paused = types.BoolValue(queue.DispatchPaused)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (cq *clusterQueueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := cq.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var plan clusterQueueResourceModel
 
 	diags := req.State.Get(ctx, &plan)
@@ -291,7 +344,7 @@ func (cq *clusterQueueResource) Delete(ctx context.Context, req resource.DeleteR
 			plan.Id.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -310,4 +363,81 @@ func updateClusterQueueResource(clusterQueueNode getClusterQueuesOrganizationClu
 	cq.Description = types.StringPointerValue(clusterQueueNode.Description)
 	cq.ClusterId = types.StringValue(clusterQueueNode.Cluster.Id)
 	cq.ClusterUuid = types.StringValue(clusterQueueNode.Cluster.Uuid)
+	cq.Paused = types.BoolValue(clusterQueueNode.DispatchPaused)
+}
+
+// terraformPauseNote is shown in the Buildkite UI on queues paused by the resource
+const terraformPauseNote = "Paused by Terraform"
+
+// PauseQueue stops jobs on a cluster queue being dispatched to agents, showing note on the queue if it isn't empty. It
+// does nothing if the queue is already paused.
+func (client *Client) PauseQueue(ctx context.Context, queueID, note string) error {
+	return client.setQueueDispatchPausedIfChanged(ctx, queueID, true, note)
+}
+
+// ResumeQueue restarts dispatching jobs on a paused cluster queue. It does nothing if the queue isn't paused.
+func (client *Client) ResumeQueue(ctx context.Context, queueID string) error {
+	return client.setQueueDispatchPausedIfChanged(ctx, queueID, false, "")
+}
+
+func (client *Client) setQueueDispatchPausedIfChanged(ctx context.Context, queueID string, paused bool, note string) error {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return err
+	}
+
+	// looked up by ID, as listing the cluster's queues only returns the first page
+	var r *getNodeResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getNode(ctx, client.genqlient, queueID)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return err
+	}
+
+	queue, ok := r.GetNode().(*getNodeNodeClusterQueue)
+	if !ok {
+		return fmt.Errorf("cluster queue %s: %w", queueID, ErrNotFound)
+	}
+	if queue.DispatchPaused == paused {
+		return nil
+	}
+	_, err = client.setQueueDispatchPaused(ctx, queueID, paused, note)
+	return err
+}
+
+// setQueueDispatchPaused pauses or resumes dispatch on the cluster queue with the given GraphQL ID. The note is only
+// used when pausing, and left off if it's empty.
+func (client *Client) setQueueDispatchPaused(ctx context.Context, queueID string, paused bool, note string) (ClusterQueueValues, error) {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return ClusterQueueValues{}, err
+	}
+
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+
+	var queue ClusterQueueValues
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		if paused {
+			r, err := pauseClusterQueueDispatch(ctx, client.genqlient, queueID, notePtr)
+			if err != nil {
+				return retryContextError(ctx, err)
+			}
+			queue = r.ClusterQueuePauseDispatch.Queue.ClusterQueueValues
+			return nil
+		}
+
+		r, err := resumeClusterQueueDispatch(ctx, client.genqlient, queueID)
+		if err != nil {
+			return retryContextError(ctx, err)
+		}
+		queue = r.ClusterQueueResumeDispatch.Queue.ClusterQueueValues
+		return nil
+	})
+	return queue, err
 }
@@ -2,6 +2,7 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -238,3 +239,61 @@ func testAccCheckClusterQueueDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestPauseQueue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		paused   bool
+		pause    bool
+		expected string
+	}{
+		"pauses a running queue":       {paused: false, pause: true, expected: "pauseClusterQueueDispatch"},
+		"resumes a paused queue":       {paused: true, pause: false, expected: "resumeClusterQueueDispatch"},
+		"leaves a paused queue alone":  {paused: true, pause: true},
+		"leaves a running queue alone": {paused: false, pause: false},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var mutations []string
+			client := newTestGraphqlClient(t, func(operation string) string {
+				if operation == "getNode" {
+					return fmt.Sprintf(`{"data": {"node": {"__typename": "ClusterQueue", "id": "UXVldWU=", "dispatchPaused": %t}}}`, tc.paused)
+				}
+				mutations = append(mutations, operation)
+				return `{"data": {}}`
+			})
+
+			var err error
+			if tc.pause {
+				err = client.PauseQueue(context.Background(), "UXVldWU=", "maintenance")
+			} else {
+				err = client.ResumeQueue(context.Background(), "UXVldWU=")
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.expected == "" && len(mutations) != 0 {
+				t.Errorf("expected no mutations, got %v", mutations)
+			}
+			if tc.expected != "" && (len(mutations) != 1 || mutations[0] != tc.expected) {
+				t.Errorf("expected a single %s mutation, got %v", tc.expected, mutations)
+			}
+		})
+	}
+
+	t.Run("errors for an unknown queue", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"node": null}}`
+		})
+
+		if err := client.PauseQueue(context.Background(), "UXVldWU=", ""); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
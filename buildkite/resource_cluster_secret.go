@@ -0,0 +1,363 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Secret is a secret stored in a Buildkite cluster. The API never returns secret values, only their metadata.
+type Secret struct {
+	ID          string     `json:"id"`
+	GraphqlID   string     `json:"graphql_id"`
+	Key         string     `json:"key"`
+	Description string     `json:"description"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+type clusterSecretResource struct {
+	client *Client
+}
+
+type clusterSecretResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	ClusterId   types.String `tfsdk:"cluster_id"`
+	ClusterUuid types.String `tfsdk:"cluster_uuid"`
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+	Description types.String `tfsdk:"description"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+func newClusterSecretResource() resource.Resource {
+	return &clusterSecretResource{}
+}
+
+func (cs *clusterSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_secret"
+}
+
+func (cs *clusterSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cs.client = req.ProviderData.(*Client)
+}
+
+func (cs *clusterSecretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resource_schema.Schema{
+		MarkdownDescription: "A Cluster Secret is a value stored in a Buildkite cluster that agents in that cluster can access at runtime. " +
+			"The secret value is never returned by the API, so changes to it made outside of Terraform are detected from the secret's last update time.",
+		Attributes: map[string]resource_schema.Attribute{
+			"id": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The GraphQL ID of the secret.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"uuid": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the secret.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": resource_schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GraphQL ID of the Cluster that this secret belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_uuid": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the Cluster that this secret belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": resource_schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The key the secret is looked up by from within a build, e.g. `DEPLOY_KEY`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": resource_schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret value. This is write-only: it is sent to Buildkite but never read back.",
+			},
+			"description": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A description of what the secret is used for.",
+			},
+			"updated_at": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the secret was last updated.",
+			},
+		},
+	}
+}
+
+func (cs *clusterSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := cs.client.RequestContext(ctx, "create")
+	defer cancel()
+
+	var plan clusterSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := cs.client.timeouts.Create(ctx, DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the secrets API is scoped by cluster UUID so look it up from the GraphQL ID
+	clusterUUID, err := cs.client.getNodeUUID(ctx, timeout, plan.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to find Cluster",
+			fmt.Sprintf("Unable to find Cluster: %s", err.Error()),
+		)
+		return
+	}
+
+	log.Printf("Creating secret %s in cluster %s ...", plan.Key.ValueString(), clusterUUID)
+	secret, err := cs.client.CreateSecret(ctx, clusterUUID, plan.Key.ValueString(), plan.Value.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Cluster Secret",
+			fmt.Sprintf("Unable to create Cluster Secret: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.ClusterUuid = types.StringValue(clusterUUID)
+	updateClusterSecretResourceState(&plan, secret)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (cs *clusterSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := cs.client.RequestContext(ctx, "read")
+	defer cancel()
+
+	var state clusterSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := cs.client.GetSecret(ctx, state.ClusterUuid.ValueString(), state.UUID.ValueString())
+	if isStatusCode(err, http.StatusNotFound) {
+		resp.Diagnostics.AddWarning("Cluster Secret not found", "Removing Cluster Secret from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Cluster Secret",
+			fmt.Sprintf("Unable to read Cluster Secret: %s", err.Error()),
+		)
+		return
+	}
+
+	// The value can't be compared with what's in Buildkite, so if the secret has been updated since Terraform last
+	// wrote it, assume the value has changed and clear it so the configured value gets written again.
+	if !state.UpdatedAt.IsNull() && secret.UpdatedAt != nil && secret.UpdatedAt.Format(time.RFC3339) != state.UpdatedAt.ValueString() {
+		log.Printf("[WARN] Secret %s was updated outside of Terraform at %s", secret.Key, secret.UpdatedAt.Format(time.RFC3339))
+		state.Value = types.StringNull()
+	}
+
+	updateClusterSecretResourceState(&state, secret)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (cs *clusterSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := cs.client.RequestContext(ctx, "update")
+	defer cancel()
+
+	var state, plan clusterSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var value, description *string
+	if !plan.Value.Equal(state.Value) {
+		value = plan.Value.ValueStringPointer()
+	}
+	if !plan.Description.Equal(state.Description) {
+		description = plan.Description.ValueStringPointer()
+		if description == nil {
+			empty := ""
+			description = &empty
+		}
+	}
+
+	log.Printf("Updating secret %s in cluster %s ...", state.Key.ValueString(), state.ClusterUuid.ValueString())
+	secret, err := cs.client.UpdateSecret(ctx, state.ClusterUuid.ValueString(), state.UUID.ValueString(), value, description)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update Cluster Secret",
+			fmt.Sprintf("Unable to update Cluster Secret: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.ClusterUuid = state.ClusterUuid
+	updateClusterSecretResourceState(&plan, secret)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (cs *clusterSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := cs.client.RequestContext(ctx, "delete")
+	defer cancel()
+
+	var state clusterSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("Deleting secret %s from cluster %s ...", state.Key.ValueString(), state.ClusterUuid.ValueString())
+	err := cs.client.DeleteSecret(ctx, state.ClusterUuid.ValueString(), state.UUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Cluster Secret",
+			fmt.Sprintf("Unable to delete Cluster Secret: %s", err.Error()),
+		)
+	}
+}
+
+func updateClusterSecretResourceState(state *clusterSecretResourceModel, secret Secret) {
+	state.ID = types.StringValue(secret.GraphqlID)
+	state.UUID = types.StringValue(secret.ID)
+	state.Key = types.StringValue(secret.Key)
+	if secret.Description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(secret.Description)
+	}
+	if secret.UpdatedAt != nil {
+		state.UpdatedAt = types.StringValue(secret.UpdatedAt.Format(time.RFC3339))
+	} else {
+		state.UpdatedAt = types.StringNull()
+	}
+}
+
+func (client *Client) secretsPath(clusterUUID string) string {
+	return fmt.Sprintf("/v2/organizations/%s/clusters/%s/secrets", client.organization, clusterUUID)
+}
+
+// CreateSecret stores a new secret in a cluster. The returned secret holds only its metadata.
+func (client *Client) CreateSecret(ctx context.Context, clusterUUID, key, value, description string) (Secret, error) {
+	var secret Secret
+
+	timeout, err := client.operationTimeout(ctx, "create")
+	if err != nil {
+		return secret, err
+	}
+
+	payload := map[string]string{
+		"key":         key,
+		"value":       value,
+		"description": description,
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPost, client.secretsPath(clusterUUID), payload, &secret)
+		return retryContextError(ctx, err)
+	})
+
+	return secret, err
+}
+
+// GetSecret fetches the metadata of a cluster secret by its UUID
+func (client *Client) GetSecret(ctx context.Context, clusterUUID, id string) (Secret, error) {
+	var secret Secret
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return secret, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, client.secretsPath(clusterUUID)+"/"+id, nil, &secret)
+		return retryContextError(ctx, err)
+	})
+
+	return secret, err
+}
+
+// UpdateSecret changes the value and/or description of a cluster secret. A nil argument leaves that field unchanged.
+func (client *Client) UpdateSecret(ctx context.Context, clusterUUID, id string, value, description *string) (Secret, error) {
+	var secret Secret
+
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return secret, err
+	}
+
+	secretPath := client.secretsPath(clusterUUID) + "/" + id
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		if description != nil {
+			err := client.makeRequest(ctx, http.MethodPut, secretPath, map[string]string{"description": *description}, &secret)
+			if err != nil {
+				return retryContextError(ctx, err)
+			}
+		}
+		if value != nil {
+			err := client.makeRequest(ctx, http.MethodPut, secretPath+"/value", map[string]string{"value": *value}, &secret)
+			if err != nil {
+				return retryContextError(ctx, err)
+			}
+		}
+		// refresh the metadata so updated_at reflects every change made above
+		err := client.makeRequest(ctx, http.MethodGet, secretPath, nil, &secret)
+		return retryContextError(ctx, err)
+	})
+
+	return secret, err
+}
+
+// DeleteSecret removes a secret from a cluster. Deleting a secret that no longer exists is not an error.
+func (client *Client) DeleteSecret(ctx context.Context, clusterUUID, id string) error {
+	timeout, err := client.operationTimeout(ctx, "delete")
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodDelete, client.secretsPath(clusterUUID)+"/"+id, nil, nil)
+		return retryContextError(ctx, err)
+	})
+
+	if isStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+
+	return err
+}
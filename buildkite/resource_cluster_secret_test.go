@@ -0,0 +1,121 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBuildkiteClusterSecretResource(t *testing.T) {
+	config := func(name, value, description string) string {
+		return fmt.Sprintf(`
+		provider "buildkite" {
+			timeouts = {
+				create = "10s"
+				read = "10s"
+				update = "10s"
+				delete = "10s"
+			}
+		}
+
+		resource "buildkite_cluster" "cluster_test" {
+			name = "Test cluster %s"
+			description = "Acceptance testing cluster"
+		}
+
+		resource "buildkite_cluster_secret" "foobar" {
+			cluster_id = buildkite_cluster.cluster_test.id
+			key = "SECRET_%s"
+			value = "%s"
+			description = "%s"
+		}
+		`, name, strings.ToUpper(name), value, description)
+	}
+
+	t.Run("creates and updates a cluster secret", func(t *testing.T) {
+		name := acctest.RandString(10)
+
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			CheckDestroy:             testAccCheckClusterSecretDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: config(name, "first", "Acceptance Test"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrSet("buildkite_cluster_secret.foobar", "uuid"),
+						resource.TestCheckResourceAttrSet("buildkite_cluster_secret.foobar", "cluster_uuid"),
+						resource.TestCheckResourceAttr("buildkite_cluster_secret.foobar", "value", "first"),
+						resource.TestCheckResourceAttr("buildkite_cluster_secret.foobar", "description", "Acceptance Test"),
+					),
+				},
+				{
+					Config: config(name, "second", "Updated Acceptance Test"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("buildkite_cluster_secret.foobar", "value", "second"),
+						resource.TestCheckResourceAttr("buildkite_cluster_secret.foobar", "description", "Updated Acceptance Test"),
+					),
+				},
+				{
+					RefreshState: true,
+					PlanOnly:     true,
+				},
+			},
+		})
+	})
+}
+
+func TestUpdateSecret(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Write([]byte(`{"id": "abc", "key": "DEPLOY_KEY", "description": "deploys", "updated_at": "2023-10-01T00:00:00Z"}`))
+	})
+
+	value := "hunter2"
+	secret, err := client.UpdateSecret(context.Background(), "cluster", "abc", &value, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if secret.Key != "DEPLOY_KEY" {
+		t.Errorf("expected key DEPLOY_KEY, got %s", secret.Key)
+	}
+
+	expected := []string{
+		"PUT /v2/organizations/test-org/clusters/cluster/secrets/abc/value",
+		"GET /v2/organizations/test-org/clusters/cluster/secrets/abc",
+	}
+	if strings.Join(requests, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+// restTestClient talks to the REST API only, which is all the secrets API needs
+var restTestClient = &Client{
+	http:         &http.Client{Transport: newHeaderRoundTripper(http.DefaultTransport, http.Header{"Authorization": []string{"Bearer " + os.Getenv("BUILDKITE_API_TOKEN")}})},
+	organization: getenv("BUILDKITE_ORGANIZATION_SLUG"),
+	restUrl:      defaultRestEndpoint,
+}
+
+func testAccCheckClusterSecretDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "buildkite_cluster_secret" {
+			continue
+		}
+
+		_, err := restTestClient.GetSecret(context.Background(), rs.Primary.Attributes["cluster_uuid"], rs.Primary.Attributes["uuid"])
+		if !isStatusCode(err, http.StatusNotFound) {
+			return fmt.Errorf("cluster secret %s still exists", rs.Primary.Attributes["uuid"])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// ClusterMaintainer is a grant allowing a team or user to manage a cluster
+type ClusterMaintainer struct {
+	ID    string `json:"id"`
+	Actor struct {
+		ID        string `json:"id"`
+		GraphqlID string `json:"graphql_id"`
+		Type      string `json:"type"`
+	} `json:"actor"`
+}
+
+type clusterTeamResource struct {
+	client *Client
+}
+
+type clusterTeamResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ClusterId   types.String `tfsdk:"cluster_id"`
+	ClusterUuid types.String `tfsdk:"cluster_uuid"`
+	TeamId      types.String `tfsdk:"team_id"`
+	TeamUuid    types.String `tfsdk:"team_uuid"`
+}
+
+func newClusterTeamResource() resource.Resource {
+	return &clusterTeamResource{}
+}
+
+func (ct *clusterTeamResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_team"
+}
+
+func (ct *clusterTeamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	ct.client = req.ProviderData.(*Client)
+}
+
+func (ct *clusterTeamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resource_schema.Schema{
+		MarkdownDescription: "Grant a team permission to manage a cluster, allowing cluster administration to be delegated.",
+		Attributes: map[string]resource_schema.Attribute{
+			"id": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the cluster maintainer grant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": resource_schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GraphQL ID of the Cluster to grant management of.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_uuid": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the Cluster.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_id": resource_schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GraphQL ID of the team that can manage the Cluster.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"team_uuid": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the team.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (ct *clusterTeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "create")
+	defer cancel()
+
+	var plan clusterTeamResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := ct.client.timeouts.Create(ctx, DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the maintainers API is keyed by UUIDs so look them up from the GraphQL IDs
+	clusterUUID, err := ct.client.getNodeUUID(ctx, timeout, plan.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to find Cluster",
+			fmt.Sprintf("Unable to find Cluster: %s", err.Error()),
+		)
+		return
+	}
+	teamUUID, err := ct.client.getNodeUUID(ctx, timeout, plan.TeamId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to find team",
+			fmt.Sprintf("Unable to find team: %s", err.Error()),
+		)
+		return
+	}
+
+	log.Printf("Granting team %s management of cluster %s ...", teamUUID, clusterUUID)
+	maintainer, err := ct.client.GrantClusterTeam(ctx, clusterUUID, teamUUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Cluster team",
+			fmt.Sprintf("Unable to create Cluster team: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(maintainer.ID)
+	plan.ClusterUuid = types.StringValue(clusterUUID)
+	plan.TeamUuid = types.StringValue(teamUUID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (ct *clusterTeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "read")
+	defer cancel()
+
+	var state clusterTeamResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maintainers, err := ct.client.ListClusterMaintainers(ctx, state.ClusterUuid.ValueString())
+	if isStatusCode(err, http.StatusNotFound) {
+		resp.Diagnostics.AddWarning("Cluster not found", "Removing Cluster team from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Cluster team",
+			fmt.Sprintf("Unable to read Cluster team: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, maintainer := range maintainers {
+		if maintainer.ID == state.ID.ValueString() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddWarning("Cluster team not found", "Removing Cluster team from state")
+	resp.State.RemoveResource(ctx)
+}
+
+// Update is never called as every attribute requires replacement
+func (ct *clusterTeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Cannot update Cluster team", "A Cluster team grant cannot be updated in place")
+}
+
+func (ct *clusterTeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := ct.client.RequestContext(ctx, "delete")
+	defer cancel()
+
+	var state clusterTeamResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("Revoking team %s management of cluster %s ...", state.TeamUuid.ValueString(), state.ClusterUuid.ValueString())
+	err := ct.client.RevokeClusterTeam(ctx, state.ClusterUuid.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Cluster team",
+			fmt.Sprintf("Unable to delete Cluster team: %s", err.Error()),
+		)
+	}
+}
+
+func (client *Client) clusterMaintainersPath(clusterUUID string) string {
+	return fmt.Sprintf("/v2/organizations/%s/clusters/%s/maintainers", client.organization, clusterUUID)
+}
+
+// ListClusterMaintainers returns every team and user grant to manage a cluster
+func (client *Client) ListClusterMaintainers(ctx context.Context, clusterUUID string) ([]ClusterMaintainer, error) {
+	var maintainers []ClusterMaintainer
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, client.clusterMaintainersPath(clusterUUID), nil, &maintainers)
+		return retryContextError(ctx, err)
+	})
+
+	return maintainers, err
+}
+
+// GrantClusterTeam allows a team to manage a cluster, returning the grant
+func (client *Client) GrantClusterTeam(ctx context.Context, clusterUUID, teamUUID string) (ClusterMaintainer, error) {
+	var maintainer ClusterMaintainer
+
+	timeout, err := client.operationTimeout(ctx, "create")
+	if err != nil {
+		return maintainer, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPost, client.clusterMaintainersPath(clusterUUID), map[string]string{"team": teamUUID}, &maintainer)
+		return retryContextError(ctx, err)
+	})
+
+	return maintainer, err
+}
+
+// RevokeClusterTeam removes a team's grant to manage a cluster. Revoking a grant that no longer exists is not an error.
+func (client *Client) RevokeClusterTeam(ctx context.Context, clusterUUID, maintainerID string) error {
+	timeout, err := client.operationTimeout(ctx, "delete")
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodDelete, client.clusterMaintainersPath(clusterUUID)+"/"+maintainerID, nil, nil)
+		return retryContextError(ctx, err)
+	})
+
+	if isStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+
+	return err
+}
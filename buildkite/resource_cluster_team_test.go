@@ -0,0 +1,87 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBuildkiteClusterTeamResource(t *testing.T) {
+	t.Run("grants a team management of a cluster", func(t *testing.T) {
+		name := acctest.RandString(10)
+
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			CheckDestroy:             testAccCheckClusterTeamDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+						resource "buildkite_cluster" "cluster" {
+							name = "Test cluster %s"
+						}
+
+						resource "buildkite_team" "team" {
+							name = "Test team %s"
+							privacy = "VISIBLE"
+							default_team = false
+							default_member_role = "MEMBER"
+						}
+
+						resource "buildkite_cluster_team" "grant" {
+							cluster_id = buildkite_cluster.cluster.id
+							team_id = buildkite_team.team.id
+						}
+					`, name, name),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrSet("buildkite_cluster_team.grant", "id"),
+						resource.TestCheckResourceAttrPair("buildkite_cluster_team.grant", "team_uuid", "buildkite_team.team", "uuid"),
+						resource.TestCheckResourceAttrPair("buildkite_cluster_team.grant", "cluster_uuid", "buildkite_cluster.cluster", "uuid"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestRevokeClusterTeam(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/v2/organizations/test-org/clusters/cluster/maintainers/grant" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if err := client.RevokeClusterTeam(context.Background(), "cluster", "grant"); err != nil {
+		t.Errorf("expected revoking a missing grant to succeed, got %s", err)
+	}
+}
+
+func testAccCheckClusterTeamDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "buildkite_cluster_team" {
+			continue
+		}
+
+		maintainers, err := restTestClient.ListClusterMaintainers(context.Background(), rs.Primary.Attributes["cluster_uuid"])
+		if isStatusCode(err, http.StatusNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, maintainer := range maintainers {
+			if maintainer.ID == rs.Primary.ID {
+				return fmt.Errorf("cluster maintainer %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -219,3 +220,43 @@ func testAccCheckClusterDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestGetClusterByName(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	client := newTestGraphqlClient(t, func(operation string) string {
+		requests++
+		if requests == 1 {
+			return `{"data": {"organization": {"clusters": {
+				"pageInfo": {"endCursor": "first", "hasNextPage": true},
+				"edges": [{"node": {"id": "Q2x1c3Rlci0tLWE=", "uuid": "a", "name": "default"}}]
+			}}}}`
+		}
+		return `{"data": {"organization": {"clusters": {
+			"pageInfo": {"endCursor": "second", "hasNextPage": false},
+			"edges": [{"node": {"id": "Q2x1c3Rlci0tLWI=", "uuid": "b", "name": "deploy"}}]
+		}}}}`
+	})
+
+	cluster, err := client.GetClusterByName(context.Background(), "deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cluster.Uuid != "b" {
+		t.Errorf("expected cluster b, got %+v", cluster)
+	}
+
+	// every cluster listed above is cached, so this doesn't search again
+	cluster, err = client.GetClusterByName(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cluster.Uuid != "a" || requests != 2 {
+		t.Errorf("expected cached cluster a after 2 requests, got %+v after %d", cluster, requests)
+	}
+
+	if _, err := client.GetClusterByName(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -2,17 +2,21 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,6 +25,7 @@ type organizationResourceModel struct {
 	ID                    types.String `tfsdk:"id"`
 	UUID                  types.String `tfsdk:"uuid"`
 	Enforce2FA            types.Bool   `tfsdk:"enforce_2fa"`
+	SSOSessionDuration    types.Int64  `tfsdk:"sso_session_duration"`
 }
 
 type organizationResource struct {
@@ -70,7 +75,9 @@ func (*organizationResource) Schema(ctx context.Context, req resource.SchemaRequ
 				ElementType: types.StringType,
 				MarkdownDescription: "A list of IP addresses in CIDR format that are allowed to access the Buildkite API." +
 					"If not set, all IP addresses are allowed (the same as setting 0.0.0.0/0).\n\n" +
-					"-> The \"Allowed API IP Addresses\" feature must be enabled on your organization in order to manage the `allowed_api_ip_addresses` attribute.",
+					"-> The \"Allowed API IP Addresses\" feature must be enabled on your organization in order to manage the `allowed_api_ip_addresses` attribute.\n\n" +
+					"A warning is shown when planning a change to an allowlist that doesn't include the public IP address Terraform is running from. " +
+					"The address is looked up with the provider's `public_ip_url`.",
 			},
 			"enforce_2fa": schema.BoolAttribute{
 				Optional:            true,
@@ -78,11 +85,82 @@ func (*organizationResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Default:             booldefault.StaticBool(false),
 				MarkdownDescription: "Sets whether the organization requires two-factor authentication for all members.",
 			},
+			"sso_session_duration": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "How many minutes members signing in through SSO stay signed in, set on each of the organization's " +
+					"enabled SSO providers. Buildkite stores the duration in hours, so this must be a multiple of 60.\n\n" +
+					"-> SSO must be enabled on your organization in order to manage the `sso_session_duration` attribute. " +
+					"The duration is left unchanged when this resource is destroyed.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(60),
+				},
+			},
 		},
 	}
 }
 
+// ModifyPlan checks the planned allowlist before it's applied, warning when it would block the address Terraform is
+// running from. The public IP is only looked up when a non-empty allowlist is changed, and the check is best effort:
+// it's skipped if the lookup is turned off or fails.
+func (o *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || o.client == nil {
+		return
+	}
+
+	var plan organizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.AllowedApiIpAddresses.IsUnknown() {
+		return
+	}
+	for _, v := range plan.AllowedApiIpAddresses.Elements() {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	cidrs := createCidrSliceFromList(plan.AllowedApiIpAddresses)
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allowed_api_ip_addresses"),
+				"Invalid CIDR range",
+				fmt.Sprintf("%q is not a valid CIDR range: %s", cidr, err.Error()),
+			)
+			return
+		}
+	}
+
+	// only look up the address when it could be newly cut off, and never when the lookup is turned off
+	if len(cidrs) == 0 || o.client.publicIPUrl == "" {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state organizationResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || plan.AllowedApiIpAddresses.Equal(state.AllowedApiIpAddresses) {
+			return
+		}
+	}
+
+	ip, err := o.client.publicIP(ctx)
+	if err != nil {
+		log.Printf("Unable to look up public IP to check the API allowlist: %s", err.Error())
+		return
+	}
+	if !allowlistContains(cidrs, ip) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("allowed_api_ip_addresses"),
+			"API allowlist excludes this machine",
+			fmt.Sprintf("The public IP address Terraform is running from (%s) isn't in allowed_api_ip_addresses, so "+
+				"once applied this provider may no longer be able to reach the Buildkite API.", ip),
+		)
+	}
+}
+
 func (o *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := o.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state organizationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -117,9 +195,17 @@ func (o *organizationResource) Create(ctx context.Context, req resource.CreateRe
 		}
 	}
 
+	if !plan.SSOSessionDuration.IsNull() {
+		if err := o.client.SetSSOSessionDuration(ctx, int(plan.SSOSessionDuration.ValueInt64())); err != nil {
+			resp.Diagnostics.AddError("Unable to set SSO session duration", err.Error())
+			return
+		}
+	}
+
 	state.ID = types.StringValue(apiResponse.OrganizationApiIpAllowlistUpdate.Organization.Id)
 	state.UUID = types.StringValue(apiResponse.OrganizationApiIpAllowlistUpdate.Organization.Uuid)
 	state.Enforce2FA = plan.Enforce2FA
+	state.SSOSessionDuration = plan.SSOSessionDuration
 	ips, diag := types.ListValueFrom(ctx, types.StringType, strings.Split(apiResponse.OrganizationApiIpAllowlistUpdate.Organization.AllowedApiIpAddresses, " "))
 	state.AllowedApiIpAddresses = ips
 
@@ -132,6 +218,9 @@ func (o *organizationResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (o *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := o.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state organizationResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -162,6 +251,20 @@ func (o *organizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// only read when managed, so organizations without SSO don't need it enabled to use this resource
+	if !state.SSOSessionDuration.IsNull() {
+		minutes, err := o.client.GetSSOSessionDuration(ctx)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			state.SSOSessionDuration = types.Int64Null()
+		case err != nil:
+			resp.Diagnostics.AddError("Unable to read SSO session duration", err.Error())
+			return
+		default:
+			state.SSOSessionDuration = types.Int64Value(int64(minutes))
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -170,9 +273,13 @@ func (o *organizationResource) ImportState(ctx context.Context, req resource.Imp
 }
 
 func (o *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan, state organizationResourceModel
+	ctx, cancel := o.client.RequestContext(ctx, "update")
+	defer cancel()
+
+	var plan, prior, state organizationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -206,6 +313,15 @@ func (o *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 		state.Enforce2FA = types.BoolValue(twoFAResponse.OrganizationEnforceTwoFactorAuthenticationForMembersUpdate.Organization.MembersRequireTwoFactorAuthentication)
 	}
 
+	// the duration is set on every SSO provider, so it's only sent when it changes
+	if !plan.SSOSessionDuration.IsNull() && !plan.SSOSessionDuration.Equal(prior.SSOSessionDuration) {
+		if err := o.client.SetSSOSessionDuration(ctx, int(plan.SSOSessionDuration.ValueInt64())); err != nil {
+			resp.Diagnostics.AddError("Unable to set SSO session duration", err.Error())
+			return
+		}
+	}
+	state.SSOSessionDuration = plan.SSOSessionDuration
+
 	state.ID = types.StringValue(apiResponse.OrganizationApiIpAllowlistUpdate.Organization.Id)
 	state.UUID = types.StringValue(apiResponse.OrganizationApiIpAllowlistUpdate.Organization.Uuid)
 	ips, diag := types.ListValueFrom(ctx, types.StringType, strings.Split(apiResponse.OrganizationApiIpAllowlistUpdate.Organization.AllowedApiIpAddresses, " "))
@@ -220,6 +336,9 @@ func (o *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (o *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := o.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	log.Printf("Deleting settings for organization %s ...", o.client.organizationId)
 
 	_, err := setApiIpAddresses(ctx, o.client.genqlient, o.client.organizationId, "")
@@ -72,6 +72,9 @@ func (organizationBannerResource) Schema(ctx context.Context, req resource.Schem
 }
 
 func (ob *organizationBannerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := ob.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state organizationBannerResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -99,7 +102,7 @@ func (ob *organizationBannerResource) Create(ctx context.Context, req resource.C
 			plan.Message.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -118,6 +121,9 @@ func (ob *organizationBannerResource) Create(ctx context.Context, req resource.C
 }
 
 func (ob *organizationBannerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := ob.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state organizationBannerResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -144,7 +150,7 @@ func (ob *organizationBannerResource) Read(ctx context.Context, req resource.Rea
 			ob.client.organization,
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -167,6 +173,9 @@ func (ob *organizationBannerResource) ImportState(ctx context.Context, req resou
 }
 
 func (ob *organizationBannerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := ob.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var plan, state organizationBannerResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -194,7 +203,7 @@ func (ob *organizationBannerResource) Update(ctx context.Context, req resource.U
 			plan.Message.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -211,6 +220,9 @@ func (ob *organizationBannerResource) Update(ctx context.Context, req resource.U
 }
 
 func (ob *organizationBannerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := ob.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state organizationBannerResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -236,7 +248,7 @@ func (ob *organizationBannerResource) Delete(ctx context.Context, req resource.D
 			ob.client.organizationId,
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
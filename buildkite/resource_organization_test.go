@@ -7,6 +7,9 @@ import (
 	"strings"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -135,3 +138,74 @@ func testAccCheckOrganizationRemoteValues(ip_addresses []string) resource.TestCh
 		return nil
 	}
 }
+
+func TestOrganizationUpdateSSOSessionDuration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	(&organizationResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	organization := func(cidr string, duration interface{}) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "T3JnYW5pemF0aW9u"),
+			"uuid": tftypes.NewValue(tftypes.String, "org-uuid"),
+			"allowed_api_ip_addresses": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, cidr),
+			}),
+			"enforce_2fa":          tftypes.NewValue(tftypes.Bool, nil),
+			"sso_session_duration": tftypes.NewValue(tftypes.Number, duration),
+		})
+	}
+
+	testCases := map[string]struct {
+		state   tftypes.Value
+		plan    tftypes.Value
+		updates int
+	}{
+		"changed duration":   {state: organization("10.0.0.0/8", 60), plan: organization("10.0.0.0/8", 120), updates: 1},
+		"new duration":       {state: organization("10.0.0.0/8", nil), plan: organization("10.0.0.0/8", 120), updates: 1},
+		"unchanged duration": {state: organization("10.0.0.0/8", 120), plan: organization("192.168.0.0/16", 120), updates: 0},
+		"no duration":        {state: organization("10.0.0.0/8", nil), plan: organization("192.168.0.0/16", nil), updates: 0},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var updates int
+			client := newTestGraphqlClient(t, func(operation string) string {
+				switch operation {
+				case "setApiIpAddresses":
+					return `{"data": {"organizationApiIpAllowlistUpdate": {"organization": {"id": "T3JnYW5pemF0aW9u", "uuid": "org-uuid", "allowedApiIpAddresses": "10.0.0.0/8"}}}}`
+				case "updateSSOProviderSession":
+					updates++
+					return `{"data": {"ssoProviderUpdate": {"ssoProvider": {"__typename": "SSOProviderSAML", "id": "U1NP", "sessionDurationInHours": 2}}}}`
+				}
+				return `{"data": {"organization": {
+					"sso": {"isEnabled": true},
+					"ssoProviders": {
+						"pageInfo": {"hasNextPage": false},
+						"edges": [{"node": {"__typename": "SSOProviderSAML", "id": "U1NP", "uuid": "1", "type": "SAML", "state": "ENABLED", "sessionDurationInHours": 1}}]
+					}
+				}}}`
+			})
+
+			req := fwresource.UpdateRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+			}
+			resp := fwresource.UpdateResponse{State: req.State}
+			(&organizationResource{client: client}).Update(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if updates != tc.updates {
+				t.Errorf("expected %d SSO provider updates, got %d", tc.updates, updates)
+			}
+		})
+	}
+}
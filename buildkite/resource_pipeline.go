@@ -2,6 +2,7 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	custom_modifier "github.com/buildkite/terraform-provider-buildkite/internal/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -68,6 +70,7 @@ type PipelineTag struct {
 
 type pipelineResourceModel struct {
 	AllowRebuilds                        types.Bool             `tfsdk:"allow_rebuilds"`
+	Archived                             types.Bool             `tfsdk:"archived"`
 	BadgeUrl                             types.String           `tfsdk:"badge_url"`
 	BranchConfiguration                  types.String           `tfsdk:"branch_configuration"`
 	CancelIntermediateBuilds             types.Bool             `tfsdk:"cancel_intermediate_builds"`
@@ -121,6 +124,7 @@ type pipelineResource struct {
 type pipelineResponse interface {
 	GetId() string
 	GetAllowRebuilds() bool
+	GetArchived() bool
 	GetBranchConfiguration() *string
 	GetCancelIntermediateBuilds() bool
 	GetCancelIntermediateBuildsBranchFilter() string
@@ -158,6 +162,9 @@ func (p *pipelineResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (p *pipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := p.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state pipelineResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -188,7 +195,7 @@ func (p *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		SkipIntermediateBuilds:               plan.SkipIntermediateBuilds.ValueBool(),
 		SkipIntermediateBuildsBranchFilter:   plan.SkipIntermediateBuildsBranchFilter.ValueString(),
 		Steps:                                PipelineStepsInput{Yaml: plan.Steps.ValueString()},
-		Tags:                                 getTagsFromSchema(&plan),
+		Tags:                                 getTagsFromSchema(&plan, p.client.managedPipelineTag(plan.Tags)),
 	}
 
 	timeouts, diags := p.client.timeouts.Create(ctx, DefaultTimeout)
@@ -202,7 +209,7 @@ func (p *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 	err := retry.RetryContext(ctx, timeouts, func() *retry.RetryError {
 		var err error
 		response, err = createPipeline(ctx, p.client.genqlient, input)
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -214,7 +221,18 @@ func (p *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	log.Printf("Successfully created pipeline with id '%s'.", response.PipelineCreate.Pipeline.Id)
 
-	setPipelineModel(&state, &response.PipelineCreate.Pipeline)
+	setPipelineModel(&state, &response.PipelineCreate.Pipeline, p.client.managedPipelineTag(plan.Tags))
+
+	if plan.Archived.ValueBool() {
+		if err := p.client.setPipelineArchived(ctx, state.Id.ValueString(), true); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to archive pipeline",
+				fmt.Sprintf("Unable to archive pipeline: %s", err.Error()),
+			)
+			return
+		}
+		state.Archived = types.BoolValue(true)
+	}
 
 	if plan.ProviderSettings != nil {
 		pipelineExtraInfo, err := updatePipelineExtraInfo(ctx, response.PipelineCreate.Pipeline.Slug, plan.ProviderSettings, p.client, timeouts)
@@ -238,6 +256,9 @@ func (p *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (p *pipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := p.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state pipelineResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -252,11 +273,15 @@ func (p *pipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	if *p.archiveOnDelete {
+		if state.Archived.ValueBool() {
+			log.Printf("Pipeline %s set to archive on delete and is already archived", state.Name.ValueString())
+			return
+		}
 		log.Printf("Pipeline %s set to archive on delete. Archiving...", state.Name.ValueString())
 
 		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 			_, err := archivePipeline(ctx, p.client.genqlient, state.Id.ValueString())
-			return retryContextError(err)
+			return retryContextError(ctx, err)
 		})
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -270,7 +295,7 @@ func (p *pipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		log.Printf("Deleting pipeline %s ...", state.Name.ValueString())
 		_, err := deletePipeline(ctx, p.client.genqlient, state.Id.ValueString())
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -286,6 +311,9 @@ func (*pipelineResource) Metadata(ctx context.Context, req resource.MetadataRequ
 }
 
 func (p *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := p.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state pipelineResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -303,7 +331,7 @@ func (p *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	err := retry.RetryContext(ctx, timeouts, func() *retry.RetryError {
 		var err error
 		response, err = getNode(ctx, p.client.genqlient, state.Id.ValueString())
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -327,7 +355,7 @@ func (p *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 			return
 		}
 
-		setPipelineModel(&state, pipelineNode)
+		setPipelineModel(&state, pipelineNode, p.client.managedPipelineTag(state.Tags))
 
 		if state.ProviderSettings != nil {
 			updatePipelineResourceExtraInfo(&state, extraInfo)
@@ -403,10 +431,13 @@ func (*pipelineResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				Optional:            true,
 				Default:             nil,
-				MarkdownDescription: "Set pipeline wide timeout for command steps.",
+				MarkdownDescription: "Set pipeline wide timeout for command steps. Must be a positive number of minutes.",
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"emoji": schema.StringAttribute{
 				Optional:            true,
@@ -416,10 +447,13 @@ func (*pipelineResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				Optional:            true,
 				Default:             nil,
-				MarkdownDescription: "Set pipeline wide maximum timeout for command steps.",
+				MarkdownDescription: "Set pipeline wide maximum timeout for command steps. Must be a positive number of minutes.",
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"description": schema.StringAttribute{
 				Computed:            true,
@@ -467,19 +501,31 @@ func (*pipelineResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The YAML steps to configure for the pipeline. Defaults to `buildkite-agent pipeline upload`.",
 			},
 			"tags": schema.SetAttribute{
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
-				MarkdownDescription: "Tags to attribute to the pipeline. Useful for searching by in the UI.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+				MarkdownDescription: "Tags to attribute to the pipeline. Useful for searching by in the UI. " +
+					"The provider also tags pipelines it manages with `managed_by:` followed by the provider's `managed_by` setting, " +
+					"`managed_by:terraform` by default, which is left out of this attribute.",
 			},
 			"webhook_url": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The webhook URL used to trigger builds from VCS providers.",
+				Sensitive:           true,
+				MarkdownDescription: "The webhook URL used to trigger builds from VCS providers. This is sensitive as it contains a token allowing builds to be triggered.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"archived": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Whether the pipeline is archived. Archived pipelines keep their build history but can't " +
+					"run new builds. If not set, the pipeline is left as it is, so it can be archived and unarchived in the Buildkite UI.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"badge_url": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The badge URL showing build state.",
@@ -623,6 +669,9 @@ func (p *pipelineResource) UpgradeState(ctx context.Context) map[int64]resource.
 }
 
 func (p *pipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := p.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var plan, state pipelineResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -653,7 +702,7 @@ func (p *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		SkipIntermediateBuilds:               plan.SkipIntermediateBuilds.ValueBool(),
 		SkipIntermediateBuildsBranchFilter:   plan.SkipIntermediateBuildsBranchFilter.ValueString(),
 		Steps:                                PipelineStepsInput{Yaml: plan.Steps.ValueString()},
-		Tags:                                 getTagsFromSchema(&plan),
+		Tags:                                 getTagsFromSchema(&plan, p.client.managedPipelineTag(plan.Tags)),
 	}
 
 	timeouts, diags := p.client.timeouts.Read(ctx, DefaultTimeout)
@@ -662,12 +711,27 @@ func (p *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// an archived pipeline is unarchived before it's updated, and archived only once the update is done
+	archive := plan.Archived.ValueBool()
+	if plan.Archived.IsUnknown() {
+		archive = state.Archived.ValueBool()
+	}
+	if state.Archived.ValueBool() {
+		if err := p.client.setPipelineArchived(ctx, plan.Id.ValueString(), false); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to unarchive pipeline",
+				fmt.Sprintf("Unable to unarchive pipeline: %s", err.Error()),
+			)
+			return
+		}
+	}
+
 	var response *updatePipelineResponse
 	err := retry.RetryContext(ctx, timeouts, func() *retry.RetryError {
 		var err error
 		log.Printf("Updating pipeline %s ...", input.Name)
 		response, err = updatePipeline(ctx, p.client.genqlient, input)
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -678,7 +742,18 @@ func (p *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	setPipelineModel(&state, &response.PipelineUpdate.Pipeline)
+	setPipelineModel(&state, &response.PipelineUpdate.Pipeline, p.client.managedPipelineTag(plan.Tags))
+
+	if archive && !state.Archived.ValueBool() {
+		if err := p.client.setPipelineArchived(ctx, plan.Id.ValueString(), true); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to archive pipeline",
+				fmt.Sprintf("Unable to archive pipeline: %s", err.Error()),
+			)
+			return
+		}
+		state.Archived = types.BoolValue(true)
+	}
 
 	if plan.ProviderSettings != nil {
 		pipelineExtraInfo, err := updatePipelineExtraInfo(ctx, response.PipelineUpdate.Pipeline.Slug, plan.ProviderSettings, p.client, timeouts)
@@ -705,11 +780,14 @@ func (*pipelineResource) ImportState(ctx context.Context, req resource.ImportSta
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func setPipelineModel(model *pipelineResourceModel, data pipelineResponse) {
+// setPipelineModel copies a pipeline into the model, leaving out managedTag so the tag the provider adds itself doesn't
+// show up as drift
+func setPipelineModel(model *pipelineResourceModel, data pipelineResponse, managedTag string) {
 	defaultTimeoutInMinutes := (*int64)(unsafe.Pointer(data.GetDefaultTimeoutInMinutes()))
 	maximumTimeoutInMinutes := (*int64)(unsafe.Pointer(data.GetMaximumTimeoutInMinutes()))
 
 	model.AllowRebuilds = types.BoolValue(data.GetAllowRebuilds())
+	model.Archived = types.BoolValue(data.GetArchived())
 	model.BranchConfiguration = types.StringPointerValue(data.GetBranchConfiguration())
 	model.CancelIntermediateBuilds = types.BoolValue(data.GetCancelIntermediateBuilds())
 	model.CancelIntermediateBuildsBranchFilter = types.StringValue(data.GetCancelIntermediateBuildsBranchFilter())
@@ -729,9 +807,12 @@ func setPipelineModel(model *pipelineResourceModel, data pipelineResponse) {
 	model.Steps = types.StringValue(data.GetSteps().Yaml)
 	model.WebhookUrl = types.StringValue(data.GetWebhookURL())
 
-	tags := make([]types.String, len(data.GetTags()))
-	for i, tag := range data.GetTags() {
-		tags[i] = types.StringValue(tag.Label)
+	tags := make([]types.String, 0, len(data.GetTags()))
+	for _, tag := range data.GetTags() {
+		if managedTag != "" && tag.Label == managedTag {
+			continue
+		}
+		tags = append(tags, types.StringValue(tag.Label))
 	}
 	model.Tags = tags
 }
@@ -775,7 +856,7 @@ func getPipelineExtraInfo(ctx context.Context, client *Client, slug string, time
 
 	err := retry.RetryContext(ctx, timeouts, func() *retry.RetryError {
 		err := client.makeRequest(ctx, "GET", fmt.Sprintf("/v2/organizations/%s/pipelines/%s", client.organization, slug), nil, &pipelineExtraInfo)
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -812,7 +893,7 @@ func updatePipelineExtraInfo(ctx context.Context, slug string, settings *provide
 	pipelineExtraInfo := PipelineExtraInfo{}
 	err := retry.RetryContext(ctx, timeouts, func() *retry.RetryError {
 		err := client.makeRequest(ctx, "PATCH", fmt.Sprintf("/v2/organizations/%s/pipelines/%s", client.organization, slug), payload, &pipelineExtraInfo)
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -821,16 +902,425 @@ func updatePipelineExtraInfo(ctx context.Context, slug string, settings *provide
 	return pipelineExtraInfo, nil
 }
 
-func getTagsFromSchema(plan *pipelineResourceModel) []PipelineTagInput {
-	tags := make([]PipelineTagInput, len(plan.Tags))
+// GetPipelineWebhookURL returns the URL VCS providers call to trigger builds of the pipeline with the given slug
+func (client *Client) GetPipelineWebhookURL(ctx context.Context, slug string) (string, error) {
+	pipeline, err := client.getPipelineBySlug(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+	return pipeline.WebhookURL, nil
+}
+
+// ArchivePipeline archives the pipeline with the given slug, keeping its build history. It does nothing if the pipeline
+// is already archived.
+func (client *Client) ArchivePipeline(ctx context.Context, slug string) error {
+	return client.setPipelineArchivedBySlug(ctx, slug, true)
+}
+
+// UnarchivePipeline restores the archived pipeline with the given slug. It does nothing if the pipeline isn't archived.
+func (client *Client) UnarchivePipeline(ctx context.Context, slug string) error {
+	return client.setPipelineArchivedBySlug(ctx, slug, false)
+}
+
+func (client *Client) setPipelineArchivedBySlug(ctx context.Context, slug string, archived bool) error {
+	pipeline, err := client.getPipelineBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if pipeline.Archived == archived {
+		return nil
+	}
+	return client.setPipelineArchived(ctx, pipeline.Id, archived)
+}
+
+// setPipelineArchived archives or unarchives the pipeline with the given GraphQL ID
+func (client *Client) setPipelineArchived(ctx context.Context, id string, archived bool) error {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		if archived {
+			_, err = archivePipeline(ctx, client.genqlient, id)
+		} else {
+			_, err = unarchivePipeline(ctx, client.genqlient, id)
+		}
+		return retryContextError(ctx, err)
+	})
+}
+
+// getPipelineBySlug fetches a pipeline in the organization, returning ErrNotFound if it doesn't exist
+func (client *Client) getPipelineBySlug(ctx context.Context, slug string) (PipelineFields, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return PipelineFields{}, err
+	}
+
+	var r *getPipelineResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getPipeline(ctx, client.genqlient, fmt.Sprintf("%s/%s", client.organization, slug))
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return PipelineFields{}, err
+	}
+
+	if r.Pipeline.Id == "" {
+		return PipelineFields{}, fmt.Errorf("pipeline %s: %w", slug, ErrNotFound)
+	}
+	return r.Pipeline.PipelineFields, nil
+}
+
+// UpdatePipelineTimeouts sets the default and maximum command step timeouts on a pipeline. A nil value leaves that
+// timeout unchanged.
+func (client *Client) UpdatePipelineTimeouts(ctx context.Context, id string, defaultTimeout, maximumTimeout *int) (*PipelineFields, error) {
+	if defaultTimeout != nil && *defaultTimeout < 1 {
+		return nil, fmt.Errorf("default timeout must be a positive number of minutes, got %d", *defaultTimeout)
+	}
+	if maximumTimeout != nil && *maximumTimeout < 1 {
+		return nil, fmt.Errorf("maximum timeout must be a positive number of minutes, got %d", *maximumTimeout)
+	}
+
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	var response *updatePipelineTimeoutsResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		response, err = updatePipelineTimeouts(ctx, client.genqlient, id, defaultTimeout, maximumTimeout)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PipelineUpdate.Pipeline.PipelineFields, nil
+}
+
+// CreatePipelineWithTeams creates a pipeline and grants each of the given teams access to it. If any grant fails the
+// pipeline is deleted again, so it isn't left behind without the team access it was expected to have.
+func (client *Client) CreatePipelineWithTeams(ctx context.Context, input PipelineCreateInput, teamAccess []TeamAccess) (*PipelineFields, error) {
+	timeout, err := client.operationTimeout(ctx, "create")
+	if err != nil {
+		return nil, err
+	}
+
+	// the pipeline create and every team grant share a single deadline
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var response *createPipelineResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		response, err = createPipeline(ctx, client.genqlient, input)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pipeline := response.PipelineCreate.Pipeline.PipelineFields
+
+	for _, access := range teamAccess {
+		err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+			_, err := createTeamPipeline(ctx, client.genqlient, access.TeamID, pipeline.Id, access.AccessLevel)
+			return retryContextError(ctx, err)
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to grant team %s access to pipeline %s: %w", access.TeamID, pipeline.Slug, err)
+			return nil, errors.Join(err, client.rollbackPipeline(pipeline.Id))
+		}
+	}
+
+	return &pipeline, nil
+}
+
+// rollbackPipeline deletes a partially configured pipeline. It doesn't reuse the caller's context because that may
+// have already hit its deadline.
+func (client *Client) rollbackPipeline(id string) error {
+	timeout, err := client.operationTimeout(context.Background(), "delete")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	log.Printf("Rolling back pipeline %s ...", id)
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		_, err := deletePipeline(ctx, client.genqlient, id)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back pipeline %s: %w", id, err)
+	}
+	return nil
+}
+
+// DeletePipeline deletes the pipeline with the given slug. Deleting a pipeline that doesn't exist succeeds, so it's
+// safe to repeat. When Buildkite refuses the deletion because builds are still running, the error says how many.
+func (client *Client) DeletePipeline(ctx context.Context, slug string) error {
+	pipeline, err := client.getPipelineBySlug(ctx, slug)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	timeout, err := client.operationTimeout(ctx, "delete")
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		_, err := deletePipeline(ctx, client.genqlient, pipeline.Id)
+		return retryContextError(ctx, err)
+	})
+	if err == nil {
+		return nil
+	}
+
+	// the mutation's error doesn't say why it was refused, so check for the most common cause
+	running, listErr := client.RecentBuilds(ctx, slug, BuildFilter{State: []string{"scheduled", "running", "canceling"}}, 100)
+	if listErr == nil && len(running) > 0 {
+		return fmt.Errorf("pipeline %s has %d unfinished builds, which must finish or be cancelled before it can be deleted: %w", slug, len(running), err)
+	}
+	return err
+}
+
+// PipelineBranchConfig controls which branches a pipeline builds and whether queued builds are skipped when a newer
+// commit is pushed. Nil fields are left unchanged.
+type PipelineBranchConfig struct {
+	BranchConfiguration                *string
+	SkipIntermediateBuilds             *bool
+	SkipIntermediateBuildsBranchFilter *string
+}
+
+// UpdatePipelineBranchConfig sets the branch filter and queued build skipping behaviour of a pipeline
+func (client *Client) UpdatePipelineBranchConfig(ctx context.Context, id string, config PipelineBranchConfig) (*PipelineFields, error) {
+	return client.updatePipelineBranchSettings(ctx, id, config.BranchConfiguration, PipelineBuildSkipping{
+		SkipIntermediateBuilds:             config.SkipIntermediateBuilds,
+		SkipIntermediateBuildsBranchFilter: config.SkipIntermediateBuildsBranchFilter,
+	})
+}
+
+// PipelineBuildSkipping controls whether queued and running builds are skipped or cancelled when a newer build is
+// created on the same branch. Nil fields are left unchanged.
+type PipelineBuildSkipping struct {
+	SkipIntermediateBuilds               *bool
+	SkipIntermediateBuildsBranchFilter   *string
+	CancelIntermediateBuilds             *bool
+	CancelIntermediateBuildsBranchFilter *string
+}
+
+// UpdatePipelineBuildSkipping sets the intermediate build skipping and cancelling behaviour of a pipeline
+func (client *Client) UpdatePipelineBuildSkipping(ctx context.Context, id string, skipping PipelineBuildSkipping) (*PipelineFields, error) {
+	return client.updatePipelineBranchSettings(ctx, id, nil, skipping)
+}
+
+// updatePipelineBranchSettings sends the branch filter and build skipping settings of a pipeline in a single mutation,
+// leaving nil settings unchanged
+func (client *Client) updatePipelineBranchSettings(ctx context.Context, id string, branchConfiguration *string, skipping PipelineBuildSkipping) (*PipelineFields, error) {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	var response *updatePipelineBranchSettingsResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		response, err = updatePipelineBranchSettings(ctx,
+			client.genqlient,
+			id,
+			branchConfiguration,
+			skipping.SkipIntermediateBuilds,
+			skipping.SkipIntermediateBuildsBranchFilter,
+			skipping.CancelIntermediateBuilds,
+			skipping.CancelIntermediateBuildsBranchFilter,
+		)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PipelineUpdate.Pipeline.PipelineFields, nil
+}
+
+// PipelineCommitStatus controls which build statuses are published back to the pipeline's repository provider. Nil
+// fields are left unchanged.
+type PipelineCommitStatus struct {
+	PublishCommitStatus        *bool
+	PublishCommitStatusPerStep *bool
+	// PublishBlockedAsPending reports builds waiting on a block step as pending rather than passed
+	PublishBlockedAsPending *bool
+}
+
+// UpdatePipelineCommitStatus sets the commit status publishing behaviour of the pipeline with the given slug, returning
+// the settings as they are after the update. Provider settings aren't in the GraphQL API, so this uses the REST API.
+func (client *Client) UpdatePipelineCommitStatus(ctx context.Context, slug string, status PipelineCommitStatus) (PipelineCommitStatus, error) {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return PipelineCommitStatus{}, err
+	}
+
+	// unset fields are omitted from the payload, so the other provider settings are left alone
+	payload := map[string]any{
+		"provider_settings": PipelineExtraSettings{
+			PublishCommitStatus:        status.PublishCommitStatus,
+			PublishCommitStatusPerStep: status.PublishCommitStatusPerStep,
+			PublishBlockedAsPending:    status.PublishBlockedAsPending,
+		},
+	}
+
+	var info PipelineExtraInfo
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, "PATCH", fmt.Sprintf("/v2/organizations/%s/pipelines/%s", client.organization, slug), payload, &info)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return PipelineCommitStatus{}, err
+	}
+
+	return PipelineCommitStatus{
+		PublishCommitStatus:        info.Provider.Settings.PublishCommitStatus,
+		PublishCommitStatusPerStep: info.Provider.Settings.PublishCommitStatusPerStep,
+		PublishBlockedAsPending:    info.Provider.Settings.PublishBlockedAsPending,
+	}, nil
+}
+
+// PipelinePresentation is how a pipeline is displayed in the Buildkite UI. Nil fields are left unchanged.
+type PipelinePresentation struct {
+	Description *string
+	// Emoji is shown alongside the pipeline name, e.g. ":buildkite:"
+	Emoji *string
+	// Color is a hex colour used behind the emoji, e.g. "#FF0000"
+	Color *string
+}
+
+// UpdatePipelinePresentation sets the description, emoji and colour of a pipeline
+func (client *Client) UpdatePipelinePresentation(ctx context.Context, id string, presentation PipelinePresentation) (*PipelineFields, error) {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	var response *updatePipelinePresentationResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		response, err = updatePipelinePresentation(ctx,
+			client.genqlient,
+			id,
+			presentation.Description,
+			presentation.Emoji,
+			presentation.Color,
+		)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PipelineUpdate.Pipeline.PipelineFields, nil
+}
+
+// UpdatePipelineDefaultBranch changes the branch a pipeline builds by default, leaving its other settings untouched
+func (client *Client) UpdatePipelineDefaultBranch(ctx context.Context, id, branch string) (*PipelineFields, error) {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	var response *updatePipelineDefaultBranchResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		response, err = updatePipelineDefaultBranch(ctx, client.genqlient, id, branch)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PipelineUpdate.Pipeline.PipelineFields, nil
+}
+
+// PipelineBuildRetention is how long a pipeline's builds are kept before Buildkite removes them. Buildkite only
+// allows these settings to be changed in the UI, so they are read only.
+type PipelineBuildRetention struct {
+	Enabled *bool
+	// Number is the minimum number of builds kept regardless of their age
+	Number *int
+	Period *BuildRetentionPeriods
+}
+
+// GetPipelineBuildRetention reads the build retention settings of a pipeline. It returns ErrUnsupported if the API
+// doesn't expose build retention and ErrNotFound if the pipeline doesn't exist.
+func (client *Client) GetPipelineBuildRetention(ctx context.Context, id string) (PipelineBuildRetention, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return PipelineBuildRetention{}, err
+	}
+
+	var response *getPipelineBuildRetentionResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		response, err = getPipelineBuildRetention(ctx, client.genqlient, id)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		// GraphQL validation fails for the whole query when a field isn't in the schema
+		if isFieldUnavailableError(err) {
+			return PipelineBuildRetention{}, fmt.Errorf("build retention: %w", ErrUnsupported)
+		}
+		return PipelineBuildRetention{}, err
+	}
+
+	pipeline, ok := response.Node.(*getPipelineBuildRetentionNodePipeline)
+	if !ok {
+		return PipelineBuildRetention{}, fmt.Errorf("pipeline %s: %w", id, ErrNotFound)
+	}
+
+	return PipelineBuildRetention{
+		Enabled: pipeline.BuildRetentionEnabled,
+		Number:  pipeline.BuildRetentionNumber,
+		Period:  pipeline.BuildRetentionPeriod,
+	}, nil
+}
+
+// getTagsFromSchema returns the planned tags, adding managedTag when it's set
+func getTagsFromSchema(plan *pipelineResourceModel, managedTag string) []PipelineTagInput {
+	tags := make([]PipelineTagInput, len(plan.Tags), len(plan.Tags)+1)
 	for i, tag := range plan.Tags {
 		tags[i] = PipelineTagInput{
 			Label: tag.ValueString(),
 		}
 	}
+	if managedTag != "" {
+		tags = append(tags, PipelineTagInput{Label: managedTag})
+	}
 	return tags
 }
 
+// managedPipelineTag returns the managed_by tag the provider adds to pipelines, or an empty string when the configured
+// tags already include it and it's managed like any other tag
+func (client *Client) managedPipelineTag(configured []types.String) string {
+	if client.managedBy == "" {
+		return ""
+	}
+	tag := "managed_by:" + client.managedBy
+	for _, t := range configured {
+		if t.ValueString() == tag {
+			return ""
+		}
+	}
+	return tag
+}
+
 // updatePipelineResourceExtraInfo updates the terraform resource with data received from Buildkite REST API
 func updatePipelineResourceExtraInfo(state *pipelineResourceModel, pipeline *PipelineExtraInfo) {
 	state.BadgeUrl = types.StringValue(pipeline.BadgeUrl)
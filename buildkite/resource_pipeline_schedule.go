@@ -115,6 +115,9 @@ func (ps *pipelineSchedule) Schema(ctx context.Context, req resource.SchemaReque
 }
 
 func (ps *pipelineSchedule) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := ps.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state pipelineScheduleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -147,7 +150,7 @@ func (ps *pipelineSchedule) Create(ctx context.Context, req resource.CreateReque
 			&envVars,
 			plan.Enabled.ValueBool())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -174,6 +177,9 @@ func (ps *pipelineSchedule) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (ps *pipelineSchedule) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := ps.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state pipelineScheduleResourceModel
 
 	diagsState := req.State.Get(ctx, &state)
@@ -198,7 +204,7 @@ func (ps *pipelineSchedule) Read(ctx context.Context, req resource.ReadRequest,
 			state.Id.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -230,6 +236,9 @@ func (ps *pipelineSchedule) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (ps *pipelineSchedule) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := ps.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state, plan pipelineScheduleResourceModel
 
 	diagsState := req.State.Get(ctx, &state)
@@ -267,7 +276,7 @@ func (ps *pipelineSchedule) Update(ctx context.Context, req resource.UpdateReque
 			input,
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -282,6 +291,9 @@ func (ps *pipelineSchedule) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (ps *pipelineSchedule) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := ps.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var plan pipelineScheduleResourceModel
 
 	diagsPlan := req.State.Get(ctx, &plan)
@@ -301,7 +313,7 @@ func (ps *pipelineSchedule) Delete(ctx context.Context, req resource.DeleteReque
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		_, err := deletePipelineSchedule(ctx, ps.client.genqlient, plan.Id.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -361,3 +373,53 @@ func updatePipelineScheduleNode(ctx context.Context, psState *pipelineScheduleRe
 	psState.Env = envVarsArrayToMap(ctx, psNode.Env)
 	psState.PipelineId = types.StringValue(psNode.Pipeline.Id)
 }
+
+// Schedule is a pipeline schedule as returned by ListPipelineSchedules
+type Schedule struct {
+	ID       string
+	UUID     string
+	Label    *string
+	Cronline *string
+	Branch   *string
+	Enabled  bool
+}
+
+// ListPipelineSchedules returns every schedule of the pipeline with the given slug, or ErrNotFound if the pipeline
+// doesn't exist
+func (client *Client) ListPipelineSchedules(ctx context.Context, pipelineSlug string) ([]Schedule, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	var r *listPipelineSchedulesResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = listPipelineSchedules(ctx, client.genqlient, fmt.Sprintf("%s/%s", client.organization, pipelineSlug))
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Pipeline.Id == "" {
+		return nil, fmt.Errorf("pipeline %s: %w", pipelineSlug, ErrNotFound)
+	}
+	// the schedules connection has no cursor, so a partial list can't be completed and would hide drift
+	if r.Pipeline.Schedules.PageInfo.HasNextPage {
+		return nil, fmt.Errorf("pipeline %s has more schedules than can be listed at once", pipelineSlug)
+	}
+
+	schedules := make([]Schedule, len(r.Pipeline.Schedules.Edges))
+	for i, edge := range r.Pipeline.Schedules.Edges {
+		schedules[i] = Schedule{
+			ID:       edge.Node.Id,
+			UUID:     edge.Node.Uuid,
+			Label:    edge.Node.Label,
+			Cronline: edge.Node.Cronline,
+			Branch:   edge.Node.Branch,
+			Enabled:  edge.Node.Enabled,
+		}
+	}
+	return schedules, nil
+}
@@ -2,6 +2,7 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -207,3 +208,46 @@ func TestAccBuildkitePipelineSchedule(t *testing.T) {
 		})
 	})
 }
+
+func TestListPipelineSchedules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the schedules", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "schedules": {
+				"pageInfo": {"hasNextPage": false},
+				"edges": [{"node": {"id": "a", "uuid": "0189d8dd", "cronline": "@daily", "branch": "main", "enabled": true}}]
+			}}}}`
+		})
+
+		schedules, err := client.ListPipelineSchedules(context.Background(), "deploy")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(schedules) != 1 || *schedules[0].Cronline != "@daily" || !schedules[0].Enabled {
+			t.Errorf("unexpected schedules: %+v", schedules)
+		}
+	})
+
+	t.Run("returns ErrNotFound for a missing pipeline", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": null}}`
+		})
+
+		_, err := client.ListPipelineSchedules(context.Background(), "deploy")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("errors instead of returning a partial list", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "schedules": {"pageInfo": {"hasNextPage": true}, "edges": []}}}}`
+		})
+
+		_, err := client.ListPipelineSchedules(context.Background(), "deploy")
+		if err == nil {
+			t.Error("expected an error for a truncated list")
+		}
+	})
+}
@@ -23,6 +23,14 @@ type pipelineTeamResourceModel struct {
 	AccessLevel types.String `tfsdk:"access_level"`
 }
 
+// TeamAccess describes the level of access a team has to a pipeline
+type TeamAccess struct {
+	TeamID string
+	// TeamSlug is only populated by ListPipelineTeams
+	TeamSlug    string
+	AccessLevel PipelineAccessLevels
+}
+
 type pipelineTeamResource struct {
 	client *Client
 }
@@ -83,6 +91,9 @@ func (pipelineTeamResource) Schema(ctx context.Context, req resource.SchemaReque
 }
 
 func (tp *pipelineTeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := tp.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var state pipelineTeamResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
@@ -108,7 +119,7 @@ func (tp *pipelineTeamResource) Create(ctx context.Context, req resource.CreateR
 			PipelineAccessLevels(state.AccessLevel.ValueString()),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -128,6 +139,9 @@ func (tp *pipelineTeamResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (tp *pipelineTeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := tp.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state pipelineTeamResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -151,7 +165,7 @@ func (tp *pipelineTeamResource) Read(ctx context.Context, req resource.ReadReque
 			state.Id.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -186,6 +200,9 @@ func (tp *pipelineTeamResource) ImportState(ctx context.Context, req resource.Im
 }
 
 func (tp *pipelineTeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := tp.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state pipelineTeamResourceModel
 	var accessLevel string
 
@@ -208,7 +225,7 @@ func (tp *pipelineTeamResource) Update(ctx context.Context, req resource.UpdateR
 			PipelineAccessLevels(accessLevel),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -225,6 +242,9 @@ func (tp *pipelineTeamResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (tp *pipelineTeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := tp.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state pipelineTeamResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -243,7 +263,7 @@ func (tp *pipelineTeamResource) Delete(ctx context.Context, req resource.DeleteR
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		_, err := deleteTeamPipeline(ctx, tp.client.genqlient, state.Id.ValueString())
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -262,3 +282,35 @@ func updateTeamPipelineResourceState(tpState *pipelineTeamResourceModel, tpNode
 	tpState.PipelineId = types.StringValue(tpNode.Pipeline.Id)
 	tpState.AccessLevel = types.StringValue(string(tpNode.PipelineAccessLevel))
 }
+
+// ListPipelineTeams returns the access every team has to the pipeline with the given GraphQL ID, or ErrNotFound if the
+// pipeline doesn't exist
+func (client *Client) ListPipelineTeams(ctx context.Context, pipelineID string) ([]TeamAccess, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]TeamAccess, pageInfo, error) {
+		r, err := listPipelineTeams(ctx, client.genqlient, pipelineID, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pipeline, ok := r.Node.(*listPipelineTeamsNodePipeline)
+		if !ok {
+			return nil, nil, fmt.Errorf("pipeline %s: %w", pipelineID, ErrNotFound)
+		}
+
+		var teams []TeamAccess
+		for _, edge := range pipeline.Teams.Edges {
+			teams = append(teams, TeamAccess{
+				TeamID:      edge.Node.Team.Id,
+				TeamSlug:    edge.Node.Team.Slug,
+				AccessLevel: edge.Node.AccessLevel,
+			})
+		}
+
+		return teams, &pipeline.Teams.PageInfo, nil
+	})
+}
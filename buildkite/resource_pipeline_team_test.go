@@ -2,6 +2,7 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -217,3 +218,46 @@ func testAccCheckPipelineTeamRemoteValues(accessLevel string, tp *pipelineTeamRe
 		return nil
 	}
 }
+
+func TestListPipelineTeams(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns teams from every page", func(t *testing.T) {
+		var page int
+		client := newTestGraphqlClient(t, func(operation string) string {
+			page++
+			if page == 1 {
+				return `{"data": {"node": {"__typename": "Pipeline", "teams": {
+					"pageInfo": {"endCursor": "first", "hasNextPage": true},
+					"edges": [{"node": {"id": "a", "accessLevel": "MANAGE_BUILD_AND_READ", "team": {"id": "VGVhbS0tLWE=", "slug": "platform"}}}]
+				}}}}`
+			}
+			return `{"data": {"node": {"__typename": "Pipeline", "teams": {
+				"pageInfo": {"endCursor": "second", "hasNextPage": false},
+				"edges": [{"node": {"id": "b", "accessLevel": "READ_ONLY", "team": {"id": "VGVhbS0tLWI=", "slug": "everyone"}}}]
+			}}}}`
+		})
+
+		teams, err := client.ListPipelineTeams(context.Background(), "UGlwZWxpbmU=")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(teams) != 2 {
+			t.Fatalf("expected teams from both pages, got %+v", teams)
+		}
+		if teams[1].TeamSlug != "everyone" || teams[1].AccessLevel != PipelineAccessLevelsReadOnly {
+			t.Errorf("unexpected team access: %+v", teams[1])
+		}
+	})
+
+	t.Run("returns ErrNotFound for a missing pipeline", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"node": null}}`
+		})
+
+		_, err := client.ListPipelineTeams(context.Background(), "UGlwZWxpbmU=")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
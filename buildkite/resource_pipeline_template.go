@@ -89,6 +89,9 @@ func (pipelineTemplateResource) Schema(ctx context.Context, req resource.SchemaR
 }
 
 func (pt *pipelineTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := pt.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state pipelineTemplateResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -119,7 +122,7 @@ func (pt *pipelineTemplateResource) Create(ctx context.Context, req resource.Cre
 			plan.Available.ValueBool(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -141,6 +144,9 @@ func (pt *pipelineTemplateResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (pt *pipelineTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := pt.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state pipelineTemplateResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -166,7 +172,7 @@ func (pt *pipelineTemplateResource) Read(ctx context.Context, req resource.ReadR
 			state.ID.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -200,6 +206,9 @@ func (pt *pipelineTemplateResource) ImportState(ctx context.Context, req resourc
 }
 
 func (pt *pipelineTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := pt.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var plan, state pipelineTemplateResourceModel
 
 	diagsState := req.State.Get(ctx, &state)
@@ -234,7 +243,7 @@ func (pt *pipelineTemplateResource) Update(ctx context.Context, req resource.Upd
 			plan.Available.ValueBool(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -254,6 +263,9 @@ func (pt *pipelineTemplateResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (pt *pipelineTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := pt.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state pipelineTemplateResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -280,7 +292,7 @@ func (pt *pipelineTemplateResource) Delete(ctx context.Context, req resource.Del
 			state.ID.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -2,10 +2,18 @@ package buildkite
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
@@ -307,6 +315,30 @@ func TestAccBuildkitePipelineResource(t *testing.T) {
 		})
 	})
 
+	t.Run("pipeline timeouts must be positive", func(t *testing.T) {
+		pipelineName := acctest.RandString(12)
+		config := fmt.Sprintf(`
+			resource "buildkite_pipeline" "pipeline" {
+				name = "%s"
+				repository = "https://github.com/buildkite/terraform-provider-buildkite.git"
+				default_timeout_in_minutes = 0
+				maximum_timeout_in_minutes = -5
+			}
+		`, pipelineName)
+
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: protoV6ProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config:      config,
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile("value must be at least 1"),
+				},
+			},
+		})
+	})
+
 	t.Run("pipeline is recreated if removed", func(t *testing.T) {
 		pipelineName := acctest.RandString(12)
 		config := fmt.Sprintf(`
@@ -571,3 +603,506 @@ func TestAccBuildkitePipelineResource(t *testing.T) {
 		})
 	})
 }
+
+func TestCreatePipelineWithTeams(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deletes the pipeline when a team grant fails", func(t *testing.T) {
+		var deleted bool
+		client := newTestGraphqlClient(t, func(operation string) string {
+			switch operation {
+			case "createPipeline":
+				return `{"data": {"pipelineCreate": {"pipeline": {"id": "UGlwZWxpbmU=", "slug": "deploy"}}}}`
+			case "deletePipeline":
+				deleted = true
+				return `{"data": {"pipelineDelete": {"clientMutationId": null}}}`
+			default:
+				return `{"errors": [{"message": "Team not found"}]}`
+			}
+		})
+
+		teams := []TeamAccess{{TeamID: "VGVhbQ==", AccessLevel: PipelineAccessLevelsReadOnly}}
+		_, err := client.CreatePipelineWithTeams(context.Background(), PipelineCreateInput{Name: "deploy"}, teams)
+		if err == nil {
+			t.Fatal("expected an error granting team access")
+		}
+		if !deleted {
+			t.Error("expected the pipeline to be rolled back")
+		}
+	})
+}
+
+func TestDeletePipeline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deletes the pipeline by its ID", func(t *testing.T) {
+		var deleted bool
+		client := newTestGraphqlClient(t, func(operation string) string {
+			switch operation {
+			case "getPipeline":
+				return `{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "slug": "deploy"}}}`
+			case "deletePipeline":
+				deleted = true
+				return `{"data": {"pipelineDelete": {"clientMutationId": null}}}`
+			}
+			t.Errorf("unexpected operation %s", operation)
+			return ""
+		})
+
+		if err := client.DeletePipeline(context.Background(), "deploy"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !deleted {
+			t.Error("expected the pipeline to be deleted")
+		}
+	})
+
+	t.Run("succeeds when the pipeline doesn't exist", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			if operation != "getPipeline" {
+				t.Errorf("unexpected operation %s", operation)
+			}
+			return `{"data": {"pipeline": null}}`
+		})
+
+		if err := client.DeletePipeline(context.Background(), "deploy"); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("explains a refusal caused by unfinished builds", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`[{"number": 4, "state": "running"}]`))
+				return
+			}
+			var body struct {
+				OperationName string `json:"operationName"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("Content-Type", "application/json")
+			if body.OperationName == "getPipeline" {
+				w.Write([]byte(`{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "slug": "deploy"}}}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"pipelineDelete": null}, "errors": [{"message": "Pipeline could not be deleted"}]}`))
+		})
+
+		err := client.DeletePipeline(context.Background(), "deploy")
+		if err == nil || !strings.Contains(err.Error(), "1 unfinished builds") {
+			t.Errorf("expected an unfinished builds error, got %v", err)
+		}
+	})
+}
+
+func TestArchivePipeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		archived bool
+		archive  bool
+		expected string
+	}{
+		"archives an active pipeline":       {archived: false, archive: true, expected: "archivePipeline"},
+		"unarchives an archived pipeline":   {archived: true, archive: false, expected: "unarchivePipeline"},
+		"leaves an archived pipeline alone": {archived: true, archive: true},
+		"leaves an active pipeline alone":   {archived: false, archive: false},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var mutations []string
+			client := newTestGraphqlClient(t, func(operation string) string {
+				if operation == "getPipeline" {
+					return fmt.Sprintf(`{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "slug": "deploy", "archived": %t}}}`, tc.archived)
+				}
+				mutations = append(mutations, operation)
+				return `{"data": {}}`
+			})
+
+			var err error
+			if tc.archive {
+				err = client.ArchivePipeline(context.Background(), "deploy")
+			} else {
+				err = client.UnarchivePipeline(context.Background(), "deploy")
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.expected == "" && len(mutations) != 0 {
+				t.Errorf("expected no mutations, got %v", mutations)
+			}
+			if tc.expected != "" && (len(mutations) != 1 || mutations[0] != tc.expected) {
+				t.Errorf("expected a single %s mutation, got %v", tc.expected, mutations)
+			}
+		})
+	}
+}
+
+func TestUpdateArchivedPipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	(&pipelineResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	pipeline := func(archived bool, description string) pipelineResourceModel {
+		return pipelineResourceModel{
+			Archived:    types.BoolValue(archived),
+			Description: types.StringValue(description),
+			Id:          types.StringValue("UGlwZWxpbmU="),
+			Name:        types.StringValue("deploy"),
+			Repository:  types.StringValue("https://github.com/buildkite/deploy.git"),
+			Slug:        types.StringValue("deploy"),
+		}
+	}
+
+	testCases := map[string]struct {
+		state     pipelineResourceModel
+		plan      pipelineResourceModel
+		mutations []string
+	}{
+		"stays archived while another attribute changes": {
+			state:     pipeline(true, "old"),
+			plan:      pipeline(true, "new"),
+			mutations: []string{"unarchivePipeline", "updatePipeline", "archivePipeline"},
+		},
+		"unarchived by the plan": {
+			state:     pipeline(true, "old"),
+			plan:      pipeline(false, "old"),
+			mutations: []string{"unarchivePipeline", "updatePipeline"},
+		},
+		"archived by the plan": {
+			state:     pipeline(false, "old"),
+			plan:      pipeline(true, "old"),
+			mutations: []string{"updatePipeline", "archivePipeline"},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var mutations []string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.Write([]byte(`{"badge_url": "https://badge.buildkite.com/deploy.svg"}`))
+					return
+				}
+				var body struct {
+					OperationName string `json:"operationName"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				mutations = append(mutations, body.OperationName)
+
+				w.Header().Set("Content-Type", "application/json")
+				if body.OperationName == "updatePipeline" {
+					w.Write([]byte(`{"data": {"pipelineUpdate": {"pipeline": {"id": "UGlwZWxpbmU=", "slug": "deploy", "archived": false}}}}`))
+					return
+				}
+				w.Write([]byte(`{"data": {}}`))
+			})
+
+			req := fwresource.UpdateRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+			}
+			req.State.Raw = tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+			req.Plan.Raw = req.State.Raw
+			if diags := req.State.Set(ctx, &tc.state); diags.HasError() {
+				t.Fatalf("unable to set state: %v", diags)
+			}
+			if diags := req.Plan.Set(ctx, &tc.plan); diags.HasError() {
+				t.Fatalf("unable to set plan: %v", diags)
+			}
+			resp := fwresource.UpdateResponse{State: req.State}
+			(&pipelineResource{client: client}).Update(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if fmt.Sprint(mutations) != fmt.Sprint(tc.mutations) {
+				t.Errorf("expected mutations %v, got %v", tc.mutations, mutations)
+			}
+
+			var state pipelineResourceModel
+			resp.State.Get(ctx, &state)
+			if !state.Archived.Equal(tc.plan.Archived) {
+				t.Errorf("expected archived to be %s, got %s", tc.plan.Archived, state.Archived)
+			}
+		})
+	}
+}
+
+func TestGetPipelineWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the webhook URL", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": {"id": "UGlwZWxpbmU=", "webhookURL": "https://webhook.buildkite.com/deliver/secret"}}}`
+		})
+
+		webhookURL, err := client.GetPipelineWebhookURL(context.Background(), "deploy")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if webhookURL != "https://webhook.buildkite.com/deliver/secret" {
+			t.Errorf("unexpected webhook URL: %s", webhookURL)
+		}
+	})
+
+	t.Run("returns ErrNotFound for a missing pipeline", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"pipeline": null}}`
+		})
+
+		_, err := client.GetPipelineWebhookURL(context.Background(), "deploy")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestUpdatePipelineBranchConfig(t *testing.T) {
+	t.Parallel()
+
+	var variables map[string]interface{}
+	client := newTestGraphqlClientWithVariables(t, func(operation string, v map[string]interface{}) string {
+		variables = v
+		return `{"data": {"pipelineUpdate": {"pipeline": {"id": "UGlwZWxpbmU=", "branchConfiguration": "main release/*", "skipIntermediateBuilds": true}}}}`
+	})
+
+	branches := "main release/*"
+	skip := true
+	pipeline, err := client.UpdatePipelineBranchConfig(context.Background(), "UGlwZWxpbmU=", PipelineBranchConfig{
+		BranchConfiguration:    &branches,
+		SkipIntermediateBuilds: &skip,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !pipeline.SkipIntermediateBuilds {
+		t.Error("expected skip_intermediate_builds to be reflected from the response")
+	}
+
+	if variables["branchConfiguration"] != "main release/*" || variables["skipIntermediateBuilds"] != true {
+		t.Errorf("expected the branch filter and build skipping to be sent, got %v", variables)
+	}
+	// settings that weren't given are left out, so they keep their current values
+	if _, ok := variables["cancelIntermediateBuilds"]; ok {
+		t.Errorf("expected unchanged settings to be omitted, got %v", variables)
+	}
+	if _, ok := variables["skipIntermediateBuildsBranchFilter"]; ok {
+		t.Errorf("expected unchanged settings to be omitted, got %v", variables)
+	}
+}
+
+func TestUpdatePipelineBuildSkipping(t *testing.T) {
+	t.Parallel()
+
+	var variables map[string]interface{}
+	client := newTestGraphqlClientWithVariables(t, func(operation string, v map[string]interface{}) string {
+		variables = v
+		return `{"data": {"pipelineUpdate": {"pipeline": {"id": "UGlwZWxpbmU=", "cancelIntermediateBuilds": true, "skipIntermediateBuilds": true}}}}`
+	})
+
+	cancel := true
+	pipeline, err := client.UpdatePipelineBuildSkipping(context.Background(), "UGlwZWxpbmU=", PipelineBuildSkipping{CancelIntermediateBuilds: &cancel})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !pipeline.CancelIntermediateBuilds {
+		t.Error("expected cancel_intermediate_builds to be reflected from the response")
+	}
+
+	// only the changed setting is sent, so the others keep their current values
+	if _, ok := variables["skipIntermediateBuilds"]; ok {
+		t.Errorf("expected unchanged settings to be omitted, got %v", variables)
+	}
+	if variables["cancelIntermediateBuilds"] != true {
+		t.Errorf("expected cancelIntermediateBuilds to be sent, got %v", variables)
+	}
+}
+
+func TestUpdatePipelineCommitStatus(t *testing.T) {
+	t.Parallel()
+
+	var payload map[string]map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/organizations/test-org/pipelines/deploy" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"provider": {"settings": {"publish_commit_status": true, "publish_commit_status_per_step": true, "publish_blocked_as_pending": false}}}`))
+	})
+
+	perStep := true
+	status, err := client.UpdatePipelineCommitStatus(context.Background(), "deploy", PipelineCommitStatus{PublishCommitStatusPerStep: &perStep})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status.PublishCommitStatus == nil || !*status.PublishCommitStatus || status.PublishBlockedAsPending == nil || *status.PublishBlockedAsPending {
+		t.Errorf("expected the current settings to be reflected from the response, got %+v", status)
+	}
+
+	settings := payload["provider_settings"]
+	if len(settings) != 1 || settings["publish_commit_status_per_step"] != true {
+		t.Errorf("expected only the changed setting to be sent, got %v", settings)
+	}
+}
+
+func TestUpdatePipelinePresentation(t *testing.T) {
+	t.Parallel()
+
+	var variables map[string]interface{}
+	client := newTestGraphqlClientWithVariables(t, func(operation string, v map[string]interface{}) string {
+		variables = v
+		return `{"data": {"pipelineUpdate": {"pipeline": {"id": "UGlwZWxpbmU=", "emoji": ":rocket:", "color": "#FF0000"}}}}`
+	})
+
+	emoji := ":rocket:"
+	pipeline, err := client.UpdatePipelinePresentation(context.Background(), "UGlwZWxpbmU=", PipelinePresentation{Emoji: &emoji})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pipeline.Emoji == nil || *pipeline.Emoji != ":rocket:" {
+		t.Errorf("expected emoji to be reflected from the response, got %v", pipeline.Emoji)
+	}
+
+	if _, ok := variables["description"]; ok {
+		t.Errorf("expected unchanged fields to be omitted, got %v", variables)
+	}
+	if variables["emoji"] != ":rocket:" {
+		t.Errorf("expected emoji to be sent, got %v", variables)
+	}
+}
+
+func TestGetPipelineBuildRetention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the retention settings", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"node": {"__typename": "Pipeline", "buildRetentionEnabled": true, "buildRetentionNumber": 50, "buildRetentionPeriod": "DAYS_90"}}}`
+		})
+
+		retention, err := client.GetPipelineBuildRetention(context.Background(), "UGlwZWxpbmU=")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !*retention.Enabled || *retention.Number != 50 || *retention.Period != BuildRetentionPeriodsDays90 {
+			t.Errorf("unexpected retention: %+v", retention)
+		}
+	})
+
+	t.Run("returns ErrUnsupported when the API doesn't have the fields", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"errors": [{"message": "Field 'buildRetentionEnabled' doesn't exist on type 'Pipeline'"}]}`
+		})
+
+		_, err := client.GetPipelineBuildRetention(context.Background(), "UGlwZWxpbmU=")
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("expected ErrUnsupported, got %v", err)
+		}
+	})
+}
+
+func TestUpdatePipelineDefaultBranch(t *testing.T) {
+	t.Parallel()
+
+	var variables map[string]interface{}
+	client := newTestGraphqlClientWithVariables(t, func(operation string, v map[string]interface{}) string {
+		variables = v
+		return `{"data": {"pipelineUpdate": {"pipeline": {"id": "UGlwZWxpbmU=", "defaultBranch": "main"}}}}`
+	})
+
+	pipeline, err := client.UpdatePipelineDefaultBranch(context.Background(), "UGlwZWxpbmU=", "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pipeline.DefaultBranch != "main" {
+		t.Errorf("expected the default branch to be reflected from the response, got %s", pipeline.DefaultBranch)
+	}
+	if len(variables) != 2 || variables["defaultBranch"] != "main" {
+		t.Errorf("expected only the id and default branch to be sent, got %v", variables)
+	}
+}
+
+func TestManagedPipelineTag(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{managedBy: "terraform"}
+
+	t.Run("is added to the planned tags", func(t *testing.T) {
+		plan := pipelineResourceModel{Tags: []types.String{types.StringValue("deploy")}}
+		tags := getTagsFromSchema(&plan, client.managedPipelineTag(plan.Tags))
+		if len(tags) != 2 || tags[1].Label != "managed_by:terraform" {
+			t.Errorf("expected the managed tag to be added, got %v", tags)
+		}
+	})
+
+	t.Run("is left to the configuration when configured", func(t *testing.T) {
+		plan := pipelineResourceModel{Tags: []types.String{types.StringValue("managed_by:terraform")}}
+		if tag := client.managedPipelineTag(plan.Tags); tag != "" {
+			t.Errorf("expected no managed tag, got %s", tag)
+		}
+		if tags := getTagsFromSchema(&plan, ""); len(tags) != 1 {
+			t.Errorf("expected the tag not to be duplicated, got %v", tags)
+		}
+	})
+
+	t.Run("can be turned off", func(t *testing.T) {
+		server := newTestGraphqlServer(t, nil)
+		client, err := NewClient(&clientConfig{
+			org:                 "test-org",
+			apiToken:            "token",
+			graphqlURL:          server.URL,
+			restURL:             server.URL,
+			disableManagedByTag: true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		plan := pipelineResourceModel{Tags: []types.String{types.StringValue("deploy")}}
+		if tags := getTagsFromSchema(&plan, client.managedPipelineTag(plan.Tags)); len(tags) != 1 {
+			t.Errorf("expected only the configured tag, got %v", tags)
+		}
+	})
+
+	t.Run("is left out of state", func(t *testing.T) {
+		var model pipelineResourceModel
+		pipeline := &PipelineFields{Tags: []PipelineFieldsTagsPipelineTag{{Label: "deploy"}, {Label: "managed_by:terraform"}}}
+		setPipelineModel(&model, pipeline, client.managedPipelineTag(nil))
+		if len(model.Tags) != 1 || model.Tags[0].ValueString() != "deploy" {
+			t.Errorf("expected only the configured tag in state, got %v", model.Tags)
+		}
+	})
+}
+
+func TestPipelineCreateInputOmitsUnsetFields(t *testing.T) {
+	t.Parallel()
+
+	body, err := json.Marshal(PipelineCreateInput{
+		OrganizationId: "T3Jn",
+		Name:           "deploy",
+		Repository:     PipelineRepositoryInput{Url: "git@github.com:buildkite/deploy.git"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var input map[string]interface{}
+	if err := json.Unmarshal(body, &input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, field := range []string{"defaultBranch", "description", "emoji", "color", "clusterId", "defaultTimeoutInMinutes", "tags", "teams"} {
+		if _, ok := input[field]; ok {
+			t.Errorf("expected unset %s to be omitted so the server default applies, got %s", field, body)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// RegistryToken is an access token for a package registry. Token is only returned when the token is created.
+type RegistryToken struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Token       string `json:"token"`
+}
+
+type registryTokenResource struct {
+	client *Client
+}
+
+type registryTokenResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	RegistrySlug types.String `tfsdk:"registry_slug"`
+	Description  types.String `tfsdk:"description"`
+	Token        types.String `tfsdk:"token"`
+}
+
+func newRegistryTokenResource() resource.Resource {
+	return &registryTokenResource{}
+}
+
+func (rt *registryTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_registry_token"
+}
+
+func (rt *registryTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	rt.client = req.ProviderData.(*Client)
+}
+
+func (rt *registryTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resource_schema.Schema{
+		MarkdownDescription: "A Registry Token grants access to a Buildkite package registry, for example to let CI publish packages.",
+		Attributes: map[string]resource_schema.Attribute{
+			"id": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"registry_slug": resource_schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The slug of the registry the token grants access to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": resource_schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "A description about what this token is used for.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": resource_schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The token value. This is only available when the token is created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (rt *registryTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := rt.client.RequestContext(ctx, "create")
+	defer cancel()
+
+	var plan registryTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("Creating token for registry %s ...", plan.RegistrySlug.ValueString())
+	token, err := rt.client.CreateRegistryToken(ctx, plan.RegistrySlug.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Registry Token",
+			fmt.Sprintf("Unable to create Registry Token: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(token.ID)
+	plan.Description = types.StringValue(token.Description)
+	plan.Token = types.StringValue(token.Token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (rt *registryTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := rt.client.RequestContext(ctx, "read")
+	defer cancel()
+
+	var state registryTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := rt.client.GetRegistryToken(ctx, state.RegistrySlug.ValueString(), state.ID.ValueString())
+	if isStatusCode(err, http.StatusNotFound) {
+		resp.Diagnostics.AddWarning("Registry Token not found", "Removing Registry Token from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Registry Token",
+			fmt.Sprintf("Unable to read Registry Token: %s", err.Error()),
+		)
+		return
+	}
+
+	// the token value is never returned after creation, so keep the one from state
+	state.Description = types.StringValue(token.Description)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every configurable attribute requires replacement
+func (rt *registryTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Cannot update Registry Token", "A Registry Token cannot be updated in place")
+}
+
+func (rt *registryTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := rt.client.RequestContext(ctx, "delete")
+	defer cancel()
+
+	var state registryTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("Revoking token %s for registry %s ...", state.ID.ValueString(), state.RegistrySlug.ValueString())
+	err := rt.client.RevokeRegistryToken(ctx, state.RegistrySlug.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Registry Token",
+			fmt.Sprintf("Unable to delete Registry Token: %s", err.Error()),
+		)
+	}
+}
+
+func (client *Client) registryTokensPath(registrySlug string) string {
+	return fmt.Sprintf("/v2/packages/organizations/%s/registries/%s/tokens", client.organization, registrySlug)
+}
+
+// CreateRegistryToken creates an access token for a package registry. This is the only time the token value is
+// returned.
+func (client *Client) CreateRegistryToken(ctx context.Context, registrySlug, description string) (RegistryToken, error) {
+	var token RegistryToken
+
+	timeout, err := client.operationTimeout(ctx, "create")
+	if err != nil {
+		return token, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodPost, client.registryTokensPath(registrySlug), map[string]string{"description": description}, &token)
+		return retryContextError(ctx, err)
+	})
+
+	return token, err
+}
+
+// GetRegistryToken fetches a registry token by its UUID. The token value isn't included.
+func (client *Client) GetRegistryToken(ctx context.Context, registrySlug, id string) (RegistryToken, error) {
+	var token RegistryToken
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return token, err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodGet, client.registryTokensPath(registrySlug)+"/"+id, nil, &token)
+		return retryContextError(ctx, err)
+	})
+
+	return token, err
+}
+
+// RevokeRegistryToken deletes a registry token. Revoking a token that no longer exists is not an error.
+func (client *Client) RevokeRegistryToken(ctx context.Context, registrySlug, id string) error {
+	timeout, err := client.operationTimeout(ctx, "delete")
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := client.makeRequest(ctx, http.MethodDelete, client.registryTokensPath(registrySlug)+"/"+id, nil, nil)
+		return retryContextError(ctx, err)
+	})
+
+	if isStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+
+	return err
+}
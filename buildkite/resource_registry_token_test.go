@@ -0,0 +1,38 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCreateRegistryToken(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/packages/organizations/test-org/registries/gems/tokens" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id": "abc", "description": "CI", "token": "bkpt_secret"}`))
+	})
+
+	token, err := client.CreateRegistryToken(context.Background(), "gems", "CI")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.ID != "abc" || token.Token != "bkpt_secret" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
+
+func TestRevokeRegistryToken(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if err := client.RevokeRegistryToken(context.Background(), "gems", "abc"); err != nil {
+		t.Errorf("expected revoking a missing token to succeed, got %s", err)
+	}
+}
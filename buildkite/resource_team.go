@@ -32,6 +32,7 @@ type teamResourceModel struct {
 	DefaultMemberRole         types.String `tfsdk:"default_member_role"`
 	Slug                      types.String `tfsdk:"slug"`
 	MembersCanCreatePipelines types.Bool   `tfsdk:"members_can_create_pipelines"`
+	MembersCanDeletePipelines types.Bool   `tfsdk:"members_can_delete_pipelines"`
 }
 
 // This is required due to the getTeam function not using Genqlient
@@ -121,15 +122,38 @@ func (t *teamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Default:             booldefault.StaticBool(false),
 				MarkdownDescription: "Whether members of the team can create Pipelines.",
 			},
+			"members_can_delete_pipelines": resource_schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether members of the team can delete Pipelines.",
+			},
 		},
 	}
 }
 
 func (t *teamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if isGraphqlID(req.ID, "Team") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	// anything else is treated as the team's slug
+	team, err := t.client.GetTeamBySlug(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import team",
+			fmt.Sprintf("Unable to find team with slug %s: %s", req.ID, err.Error()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), team.ID)...)
 }
 
 func (t *teamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := t.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var state teamResourceModel
 
 	diags := req.Plan.Get(ctx, &state)
@@ -158,9 +182,10 @@ func (t *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 			state.IsDefaultTeam.ValueBool(),
 			state.DefaultMemberRole.ValueString(),
 			state.MembersCanCreatePipelines.ValueBool(),
+			state.MembersCanDeletePipelines.ValueBool(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -179,6 +204,9 @@ func (t *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (t *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := t.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state teamResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -203,7 +231,7 @@ func (t *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			state.ID.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -232,6 +260,9 @@ func (t *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (t *teamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := t.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state, plan teamResourceModel
 	diagsState := req.State.Get(ctx, &state)
 	diagsPlan := req.Plan.Get(ctx, &plan)
@@ -243,30 +274,32 @@ func (t *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	timeout, diags := t.client.timeouts.Update(ctx, DefaultTimeout)
-	resp.Diagnostics.Append(diags...)
-
-	if resp.Diagnostics.HasError() {
-		return
+	input := TeamUpdateInput{}
+	if !plan.Name.Equal(state.Name) {
+		input.Name = plan.Name.ValueStringPointer()
+	}
+	if !plan.Description.Equal(state.Description) {
+		// an empty description removes it
+		description := plan.Description.ValueString()
+		input.Description = &description
+	}
+	if !plan.Privacy.Equal(state.Privacy) {
+		input.Privacy = plan.Privacy.ValueStringPointer()
+	}
+	if !plan.IsDefaultTeam.Equal(state.IsDefaultTeam) {
+		input.IsDefaultTeam = plan.IsDefaultTeam.ValueBoolPointer()
+	}
+	if !plan.DefaultMemberRole.Equal(state.DefaultMemberRole) {
+		input.DefaultMemberRole = plan.DefaultMemberRole.ValueStringPointer()
+	}
+	if !plan.MembersCanCreatePipelines.Equal(state.MembersCanCreatePipelines) {
+		input.MembersCanCreatePipelines = plan.MembersCanCreatePipelines.ValueBoolPointer()
+	}
+	if !plan.MembersCanDeletePipelines.Equal(state.MembersCanDeletePipelines) {
+		input.MembersCanDeletePipelines = plan.MembersCanDeletePipelines.ValueBoolPointer()
 	}
 
-	var response *teamUpdateResponse
-	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
-		var err error
-		response, err = teamUpdate(ctx,
-			t.client.genqlient,
-			state.ID.ValueString(),
-			plan.Name.ValueString(),
-			plan.Description.ValueStringPointer(),
-			plan.Privacy.ValueString(),
-			plan.IsDefaultTeam.ValueBool(),
-			plan.DefaultMemberRole.ValueString(),
-			plan.MembersCanCreatePipelines.ValueBool(),
-		)
-
-		return retryContextError(err)
-	})
-
+	team, err := t.client.UpdateTeam(ctx, state.ID.ValueString(), input)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update Team",
@@ -275,11 +308,14 @@ func (t *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	plan.Slug = types.StringValue(response.TeamUpdate.Team.Slug)
+	plan.Slug = types.StringValue(team.Slug)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (t *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := t.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state teamResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -302,7 +338,7 @@ func (t *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 			state.ID.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -324,4 +360,102 @@ func updateTeamResourceState(state *teamResourceModel, res getNodeNodeTeam) {
 	state.IsDefaultTeam = types.BoolValue(res.IsDefaultTeam)
 	state.DefaultMemberRole = types.StringValue(string(res.GetDefaultMemberRole()))
 	state.MembersCanCreatePipelines = types.BoolValue(res.MembersCanCreatePipelines)
+	state.MembersCanDeletePipelines = types.BoolValue(res.MembersCanDeletePipelines)
+}
+
+// TeamUpdateInput holds the team settings to change. Nil fields are left as they are.
+type TeamUpdateInput struct {
+	Name                      *string
+	Description               *string
+	Privacy                   *string
+	IsDefaultTeam             *bool
+	DefaultMemberRole         *string
+	MembersCanCreatePipelines *bool
+	MembersCanDeletePipelines *bool
+}
+
+// UpdateTeam changes the given settings of a team in place and returns the updated team
+func (client *Client) UpdateTeam(ctx context.Context, teamID string, input TeamUpdateInput) (Team, error) {
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return Team{}, err
+	}
+
+	var team Team
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		// the API requires the name, default team and default member role on every update, so any that aren't
+		// changing are filled in from the team's current settings
+		name, isDefaultTeam, defaultMemberRole := input.Name, input.IsDefaultTeam, input.DefaultMemberRole
+		if name == nil || isDefaultTeam == nil || defaultMemberRole == nil {
+			r, err := getNode(ctx, client.genqlient, teamID)
+			if err != nil {
+				return retryContextError(ctx, err)
+			}
+			current, ok := r.GetNode().(*getNodeNodeTeam)
+			if !ok || current == nil {
+				return retry.NonRetryableError(fmt.Errorf("team %s: %w", teamID, ErrNotFound))
+			}
+			if name == nil {
+				name = &current.Name
+			}
+			if isDefaultTeam == nil {
+				isDefaultTeam = &current.IsDefaultTeam
+			}
+			if defaultMemberRole == nil {
+				defaultMemberRole = &current.DefaultMemberRole
+			}
+		}
+
+		r, err := teamUpdate(ctx,
+			client.genqlient,
+			teamID,
+			*name,
+			input.Description,
+			input.Privacy,
+			*isDefaultTeam,
+			*defaultMemberRole,
+			input.MembersCanCreatePipelines,
+			input.MembersCanDeletePipelines,
+		)
+		if err != nil {
+			return retryContextError(ctx, err)
+		}
+
+		team = newTeam(r.TeamUpdate.Team.TeamFields)
+		return nil
+	})
+
+	return team, err
+}
+
+// UpdateTeamPipelinePermissions sets whether members of a team can create and delete pipelines. A nil value leaves
+// that permission unchanged.
+func (client *Client) UpdateTeamPipelinePermissions(ctx context.Context, teamID string, canCreate, canDelete *bool) (Team, error) {
+	return client.UpdateTeam(ctx, teamID, TeamUpdateInput{
+		MembersCanCreatePipelines: canCreate,
+		MembersCanDeletePipelines: canDelete,
+	})
+}
+
+// GetTeamBySlug looks up a team in the organization by its slug, returning ErrNotFound if there's no such team
+func (client *Client) GetTeamBySlug(ctx context.Context, slug string) (Team, error) {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return Team{}, err
+	}
+
+	var r *GetTeamFromSlugResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = GetTeamFromSlug(ctx, client.genqlient, fmt.Sprintf("%s/%s", client.organization, slug))
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return Team{}, err
+	}
+
+	if r.Team.Id == "" {
+		return Team{}, fmt.Errorf("team %s: %w", slug, ErrNotFound)
+	}
+	return newTeam(r.Team.TeamFields), nil
 }
@@ -82,6 +82,9 @@ func (teamMemberResource) Schema(ctx context.Context, req resource.SchemaRequest
 }
 
 func (tm *teamMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := tm.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var state teamMemberResourceModel
 
 	diags := req.Plan.Get(ctx, &state)
@@ -109,7 +112,7 @@ func (tm *teamMemberResource) Create(ctx context.Context, req resource.CreateReq
 			*state.Role.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -130,6 +133,9 @@ func (tm *teamMemberResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (tm *teamMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := tm.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state teamMemberResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -155,7 +161,7 @@ func (tm *teamMemberResource) Read(ctx context.Context, req resource.ReadRequest
 			state.Id.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -189,6 +195,9 @@ func (tm *teamMemberResource) ImportState(ctx context.Context, req resource.Impo
 }
 
 func (tm *teamMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := tm.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state, plan teamMemberResourceModel
 
 	// Obtain team member's ID from state, new role from plan
@@ -219,7 +228,7 @@ func (tm *teamMemberResource) Update(ctx context.Context, req resource.UpdateReq
 			*plan.Role.ValueStringPointer(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -236,6 +245,9 @@ func (tm *teamMemberResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (tm *teamMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := tm.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state teamMemberResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -259,7 +271,7 @@ func (tm *teamMemberResource) Delete(ctx context.Context, req resource.DeleteReq
 			state.Id.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -2,6 +2,7 @@ package buildkite
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -243,3 +244,116 @@ func testAccCheckTeamResourceDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestUpdateTeam(t *testing.T) {
+	t.Parallel()
+
+	var updateVariables map[string]interface{}
+	client := newTestGraphqlClientWithVariables(t, func(operation string, variables map[string]interface{}) string {
+		switch operation {
+		case "getNode":
+			return `{"data": {"node": {"__typename": "Team", "id": "VGVhbQ==", "name": "Platform", "privacy": "VISIBLE", "isDefaultTeam": true, "defaultMemberRole": "MEMBER"}}}`
+		case "teamUpdate":
+			updateVariables = variables
+			return `{"data": {"teamUpdate": {"team": {"id": "VGVhbQ==", "slug": "platform", "name": "Platform", "privacy": "SECRET", "isDefaultTeam": true, "defaultMemberRole": "MEMBER"}}}}`
+		}
+		t.Errorf("unexpected operation %s", operation)
+		return ""
+	})
+
+	privacy := "SECRET"
+	team, err := client.UpdateTeam(context.Background(), "VGVhbQ==", TeamUpdateInput{Privacy: &privacy})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if team.Privacy != "SECRET" {
+		t.Errorf("expected privacy SECRET, got %s", team.Privacy)
+	}
+
+	// unchanged required fields come from the current team and unchanged optional fields are left out
+	if updateVariables["name"] != "Platform" || updateVariables["isDefaultTeam"] != true {
+		t.Errorf("expected current settings to be sent, got %v", updateVariables)
+	}
+	if _, ok := updateVariables["membersCanCreatePipelines"]; ok {
+		t.Errorf("expected membersCanCreatePipelines to be omitted, got %v", updateVariables)
+	}
+}
+
+func TestUpdateTeamNotFound(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGraphqlClient(t, func(operation string) string {
+		if operation != "getNode" {
+			t.Errorf("unexpected operation %s", operation)
+		}
+		return `{"data": {"node": null}}`
+	})
+
+	privacy := "SECRET"
+	_, err := client.UpdateTeam(context.Background(), "VGVhbQ==", TeamUpdateInput{Privacy: &privacy})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUpdateTeamPipelinePermissions(t *testing.T) {
+	t.Parallel()
+
+	var updateVariables map[string]interface{}
+	client := newTestGraphqlClientWithVariables(t, func(operation string, variables map[string]interface{}) string {
+		switch operation {
+		case "getNode":
+			return `{"data": {"node": {"__typename": "Team", "id": "VGVhbQ==", "name": "Platform", "privacy": "VISIBLE", "isDefaultTeam": false, "defaultMemberRole": "MEMBER"}}}`
+		case "teamUpdate":
+			updateVariables = variables
+			return `{"data": {"teamUpdate": {"team": {"id": "VGVhbQ==", "slug": "platform", "name": "Platform", "membersCanCreatePipelines": true, "membersCanDeletePipelines": true}}}}`
+		}
+		t.Errorf("unexpected operation %s", operation)
+		return ""
+	})
+
+	canDelete := true
+	team, err := client.UpdateTeamPipelinePermissions(context.Background(), "VGVhbQ==", nil, &canDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !team.MembersCanDeletePipelines {
+		t.Error("expected the delete permission to be reflected from the response")
+	}
+
+	if updateVariables["membersCanDeletePipelines"] != true {
+		t.Errorf("expected membersCanDeletePipelines to be sent, got %v", updateVariables)
+	}
+	if _, ok := updateVariables["membersCanCreatePipelines"]; ok {
+		t.Errorf("expected the unchanged create permission to be omitted, got %v", updateVariables)
+	}
+}
+
+func TestGetTeamBySlug(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the team", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"team": {"id": "VGVhbS0tLTRiM2E=", "slug": "platform", "privacy": "VISIBLE"}}}`
+		})
+
+		team, err := client.GetTeamBySlug(context.Background(), "platform")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if team.ID != "VGVhbS0tLTRiM2E=" {
+			t.Errorf("unexpected team: %+v", team)
+		}
+	})
+
+	t.Run("returns ErrNotFound for a missing team", func(t *testing.T) {
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"team": null}}`
+		})
+
+		_, err := client.GetTeamBySlug(context.Background(), "platform")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
@@ -50,6 +50,9 @@ func (ts *testSuiteResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (ts *testSuiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := ts.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var plan, state testSuiteModel
 	var response testSuiteResponse
 	payload := map[string]interface{}{}
@@ -76,7 +79,7 @@ func (ts *testSuiteResource) Create(ctx context.Context, req resource.CreateRequ
 			plan.TeamOwnerId.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -109,7 +112,7 @@ func (ts *testSuiteResource) Create(ctx context.Context, req resource.CreateRequ
 	createErr := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		err = ts.client.makeRequest(ctx, "POST", url, payload, &response)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if createErr != nil {
@@ -132,6 +135,9 @@ func (ts *testSuiteResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (ts *testSuiteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := ts.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state testSuiteModel
 
 	diags := req.State.Get(ctx, &state)
@@ -149,7 +155,7 @@ func (ts *testSuiteResource) Delete(ctx context.Context, req resource.DeleteRequ
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		err := ts.client.makeRequest(ctx, "DELETE", url, nil, nil)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -166,6 +172,9 @@ func (*testSuiteResource) Metadata(ctx context.Context, req resource.MetadataReq
 }
 
 func (ts *testSuiteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := ts.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state testSuiteModel
 
 	diags := req.State.Get(ctx, &state)
@@ -190,7 +199,7 @@ func (ts *testSuiteResource) Read(ctx context.Context, req resource.ReadRequest,
 			50,
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -287,6 +296,9 @@ func (ts *testSuiteResource) Schema(ctx context.Context, req resource.SchemaRequ
 }
 
 func (ts *testSuiteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := ts.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var plan, state testSuiteModel
 	var response testSuiteResponse
 	payload := map[string]interface{}{}
@@ -316,7 +328,7 @@ func (ts *testSuiteResource) Update(ctx context.Context, req resource.UpdateRequ
 	updateErr := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		err := ts.client.makeRequest(ctx, "PATCH", url, payload, &response)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if updateErr != nil {
@@ -343,7 +355,7 @@ func (ts *testSuiteResource) Update(ctx context.Context, req resource.UpdateRequ
 				SuiteAccessLevelsManageAndRead,
 			)
 
-			return retryContextError(err)
+			return retryContextError(ctx, err)
 		})
 
 		if err != nil {
@@ -363,7 +375,7 @@ func (ts *testSuiteResource) Update(ctx context.Context, req resource.UpdateRequ
 						team.Node.Id,
 					)
 
-					return retryContextError(err)
+					return retryContextError(ctx, err)
 				})
 
 				if err != nil {
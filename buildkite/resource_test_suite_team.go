@@ -82,6 +82,9 @@ func (tst *testSuiteTeamResource) Schema(ctx context.Context, req resource.Schem
 }
 
 func (tst *testSuiteTeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := tst.client.RequestContext(ctx, "create")
+	defer cancel()
+
 	var state testSuiteTeamModel
 
 	diags := req.Plan.Get(ctx, &state)
@@ -109,7 +112,7 @@ func (tst *testSuiteTeamResource) Create(ctx context.Context, req resource.Creat
 			SuiteAccessLevels(state.AccessLevel.ValueString()),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -128,6 +131,9 @@ func (tst *testSuiteTeamResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (tst *testSuiteTeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := tst.client.RequestContext(ctx, "read")
+	defer cancel()
+
 	var state testSuiteTeamModel
 
 	diags := req.State.Get(ctx, &state)
@@ -153,7 +159,7 @@ func (tst *testSuiteTeamResource) Read(ctx context.Context, req resource.ReadReq
 			state.ID.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -188,6 +194,9 @@ func (tst *testSuiteTeamResource) ImportState(ctx context.Context, req resource.
 }
 
 func (tst *testSuiteTeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := tst.client.RequestContext(ctx, "update")
+	defer cancel()
+
 	var state testSuiteTeamModel
 	var testSuiteTeamAccessLevel string
 
@@ -218,7 +227,7 @@ func (tst *testSuiteTeamResource) Update(ctx context.Context, req resource.Updat
 			SuiteAccessLevels(testSuiteTeamAccessLevel),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
@@ -236,6 +245,9 @@ func (tst *testSuiteTeamResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (tst *testSuiteTeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := tst.client.RequestContext(ctx, "delete")
+	defer cancel()
+
 	var state testSuiteTeamModel
 
 	diags := req.State.Get(ctx, &state)
@@ -259,7 +271,7 @@ func (tst *testSuiteTeamResource) Delete(ctx context.Context, req resource.Delet
 			state.ID.ValueString(),
 		)
 
-		return retryContextError(err)
+		return retryContextError(ctx, err)
 	})
 
 	if err != nil {
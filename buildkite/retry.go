@@ -0,0 +1,168 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy overrides how REST requests made with a context from WithRetryPolicy are retried. Without one, requests
+// are retried by the caller until the operation times out, after rate limited and unavailable responses and transient
+// network errors, see isRetryableError.
+//
+// Either way, a request that isn't idempotent, like a POST or a rebuild, is only retried after an error response other
+// than a server error, as Buildkite has then refused it. A server error or a failed connection may come after the
+// request was applied, so retrying it could create something twice. Requests made with a context from
+// WithIdempotencyKey, or with a policy that sets RetryNonIdempotent, are retried like any other.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a request is sent, including the first
+	MaxAttempts int
+	// Base is the delay before the first retry, doubling for each retry after that
+	Base time.Duration
+	// Max caps the delay between retries
+	Max time.Duration
+	// RetryOn reports whether a response with the given status code should be retried. Defaults to retrying rate
+	// limited and unavailable responses. Transient network errors are always retried.
+	RetryOn func(status int) bool
+	// Reconcile is called before each retry with the error the request failed with, so the caller can re-read whatever
+	// it conflicted with. Returning true stops retrying and treats the request as successful, e.g. when a racing create
+	// already made the same resource; the response object isn't filled in, so Reconcile should set it from what it read.
+	Reconcile func(ctx context.Context, err error) (bool, error)
+	// RetryNonIdempotent retries POST and PATCH requests after server and network errors too, for callers that know
+	// repeating their request is safe
+	RetryNonIdempotent bool
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns a context that makes REST requests retry according to policy instead of the client defaults
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// WithNoRetry returns a context that makes REST requests run exactly once, failing straight away rather than retrying.
+// It's meant for latency sensitive calls like health checks.
+func WithNoRetry(ctx context.Context) context.Context {
+	return WithRetryPolicy(ctx, RetryPolicy{MaxAttempts: 1})
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context that sends key as the Idempotency-Key header of REST requests, marking them as
+// safe to retry whatever their method
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKey{}).(string)
+	return key, ok && key != ""
+}
+
+type notIdempotentKey struct{}
+
+// withNotIdempotent returns a context marking REST requests as unsafe to repeat whatever their method, for endpoints
+// like rebuild that create something despite being a PUT
+func withNotIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, notIdempotentKey{}, true)
+}
+
+func notIdempotentFromContext(ctx context.Context) bool {
+	notIdempotent, _ := ctx.Value(notIdempotentKey{}).(bool)
+	return notIdempotent
+}
+
+// isIdempotent reports whether sending a request with the given method more than once has the same effect as sending
+// it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// canRetry reports whether a request that failed with err may be sent again. Error responses below 500 mean the
+// request wasn't applied, so they're always safe to retry.
+func canRetry(ctx context.Context, method string, err error) bool {
+	if isIdempotent(method) && !notIdempotentFromContext(ctx) {
+		return true
+	}
+	if _, ok := idempotencyKeyFromContext(ctx); ok {
+		return true
+	}
+	if policy, ok := retryPolicyFromContext(ctx); ok && policy.RetryNonIdempotent {
+		return true
+	}
+
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError
+}
+
+// RetryOnConflict is a RetryOn for creates that can race with another create of the same thing, retrying 409 Conflict
+// responses along with the usual rate limited and unavailable ones. It's meant to be paired with Reconcile.
+func RetryOnConflict(status int) bool {
+	return status == http.StatusConflict || defaultRetryOn(status)
+}
+
+func defaultRetryOn(status int) bool {
+	return status == http.StatusTooManyRequests || (status >= http.StatusBadGateway && status <= http.StatusGatewayTimeout)
+}
+
+func retryPolicyFromContext(ctx context.Context) (RetryPolicy, bool) {
+	policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return policy, ok
+}
+
+func (p RetryPolicy) shouldRetry(err error) bool {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return isTransientNetworkError(err)
+	}
+	if p.RetryOn != nil {
+		return p.RetryOn(apiErr.StatusCode)
+	}
+	return defaultRetryOn(apiErr.StatusCode)
+}
+
+// delay returns how long to wait before the given retry, counting from 1
+func (p RetryPolicy) delay(retry int) time.Duration {
+	delay := p.Base << (retry - 1)
+	if p.Max > 0 && (delay > p.Max || delay <= 0) {
+		delay = p.Max
+	}
+	return delay
+}
+
+// logRetry records that a request is about to be retried, so retries can be told apart from slow responses
+func logRetry(ctx context.Context, method, path string, attempt int, err error, delay time.Duration) {
+	fields := map[string]interface{}{
+		"method":  method,
+		"path":    path,
+		"attempt": attempt,
+		"error":   err.Error(),
+		"delay":   delay.String(),
+	}
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		fields["status_code"] = apiErr.StatusCode
+	}
+	tflog.Warn(ctx, "Retrying Buildkite API request", fields)
+}
+
+// retryPolicyError wraps an error a RetryPolicy has given up on, or that isn't safe to retry, so callers' own retry
+// loops don't retry it again
+type retryPolicyError struct {
+	err error
+}
+
+func (e *retryPolicyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryPolicyError) Unwrap() error {
+	return e.err
+}
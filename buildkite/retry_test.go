@@ -0,0 +1,310 @@
+package buildkite
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		Base:        time.Millisecond,
+		Max:         5 * time.Millisecond,
+		RetryOn: func(status int) bool {
+			return status == http.StatusConflict
+		},
+	}
+
+	t.Run("retries until the request succeeds", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests < 3 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.Write([]byte(`{"number": 1}`))
+		})
+
+		var build Build
+		err := client.makeRequest(WithRetryPolicy(context.Background(), policy), http.MethodPost, "/v2/builds", nil, &build)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if requests != 3 {
+			t.Errorf("expected 3 requests, got %d", requests)
+		}
+	})
+
+	t.Run("gives up after the maximum attempts", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusConflict)
+		})
+
+		err := client.makeRequest(WithRetryPolicy(context.Background(), policy), http.MethodPost, "/v2/builds", nil, nil)
+		if !isStatusCode(err, http.StatusConflict) {
+			t.Fatalf("expected a conflict error, got %v", err)
+		}
+		if requests != 3 {
+			t.Errorf("expected 3 requests, got %d", requests)
+		}
+		if isRetryableError(err) {
+			t.Error("expected the caller not to retry an error the policy gave up on")
+		}
+	})
+
+	t.Run("doesn't retry other status codes", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		client.makeRequest(WithRetryPolicy(context.Background(), policy), http.MethodGet, "/v2/builds", nil, nil)
+		if requests != 1 {
+			t.Errorf("expected 1 request, got %d", requests)
+		}
+	})
+}
+
+func TestRetryPolicyReconcile(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 3, Base: time.Millisecond, RetryOn: RetryOnConflict}
+
+	t.Run("adopts what a conflicting request created", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusConflict)
+		})
+
+		var build Build
+		reconciling := policy
+		reconciling.Reconcile = func(ctx context.Context, err error) (bool, error) {
+			if !isStatusCode(err, http.StatusConflict) {
+				t.Errorf("expected to reconcile a conflict, got %v", err)
+			}
+			build.Number = 7
+			return true, nil
+		}
+
+		err := client.makeRequest(WithRetryPolicy(context.Background(), reconciling), http.MethodPost, "/v2/builds", nil, &build)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if requests != 1 || build.Number != 7 {
+			t.Errorf("expected the reconciled build after 1 request, got build %d after %d", build.Number, requests)
+		}
+	})
+
+	t.Run("retries when there's nothing to adopt", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.Write([]byte(`{"number": 1}`))
+		})
+
+		var reconciled int
+		reconciling := policy
+		reconciling.Reconcile = func(ctx context.Context, err error) (bool, error) {
+			reconciled++
+			return false, nil
+		}
+
+		var build Build
+		err := client.makeRequest(WithRetryPolicy(context.Background(), reconciling), http.MethodPost, "/v2/builds", nil, &build)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if requests != 2 || reconciled != 1 || build.Number != 1 {
+			t.Errorf("expected 2 requests and 1 reconcile, got %d and %d", requests, reconciled)
+		}
+	})
+
+	t.Run("conflicts aren't retried by default", func(t *testing.T) {
+		var requests int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusConflict)
+		})
+
+		client.makeRequest(WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 3, Base: time.Millisecond}), http.MethodPost, "/v2/builds", nil, nil)
+		if requests != 1 {
+			t.Errorf("expected 1 request, got %d", requests)
+		}
+	})
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 3, Base: time.Millisecond}
+
+	testCases := map[string]struct {
+		ctx      context.Context
+		method   string
+		expected int
+	}{
+		"POST isn't retried":                    {ctx: WithRetryPolicy(context.Background(), policy), method: http.MethodPost, expected: 1},
+		"PATCH isn't retried":                   {ctx: WithRetryPolicy(context.Background(), policy), method: http.MethodPatch, expected: 1},
+		"PUT is retried":                        {ctx: WithRetryPolicy(context.Background(), policy), method: http.MethodPut, expected: 3},
+		"POST with an idempotency key":          {ctx: WithIdempotencyKey(WithRetryPolicy(context.Background(), policy), "create-1"), method: http.MethodPost, expected: 3},
+		"POST with a policy allowing the retry": {ctx: WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 3, Base: time.Millisecond, RetryNonIdempotent: true}), method: http.MethodPost, expected: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if _, ok := idempotencyKeyFromContext(tc.ctx); ok && r.Header.Get("Idempotency-Key") != "create-1" {
+					t.Errorf("expected the idempotency key to be sent, got %q", r.Header.Get("Idempotency-Key"))
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+
+			err := client.makeRequest(tc.ctx, tc.method, "/v2/builds", nil, nil)
+			if !isStatusCode(err, http.StatusServiceUnavailable) {
+				t.Fatalf("expected a 503, got %v", err)
+			}
+			if requests != tc.expected {
+				t.Errorf("expected %d requests, got %d", tc.expected, requests)
+			}
+		})
+	}
+
+	t.Run("callers don't retry a POST that may have been applied", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			// drop the connection without responding, as if it failed after the request was received
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("unable to hijack the connection: %s", err)
+				return
+			}
+			conn.Close()
+		})
+
+		err := client.makeRequest(context.Background(), http.MethodPost, "/v2/builds", nil, nil)
+		if err == nil || isRetryableError(err) {
+			t.Errorf("expected an error that isn't retried, got %v", err)
+		}
+
+		err = client.makeRequest(context.Background(), http.MethodGet, "/v2/builds", nil, nil)
+		if err == nil || !isRetryableError(err) {
+			t.Errorf("expected a GET to be retryable, got %v", err)
+		}
+	})
+}
+
+func TestWithNoRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.GetBuild(WithNoRetry(context.Background()), "deploy", 1)
+	if !isStatusCode(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected an unavailable error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{Base: 100 * time.Millisecond, Max: time.Second}
+	for retry, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 70: time.Second} {
+		if got := policy.delay(retry); got != expected {
+			t.Errorf("expected retry %d to wait %s, got %s", retry, expected, got)
+		}
+	}
+}
+
+func TestRetryPolicyLogsRetries(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	policy := RetryPolicy{MaxAttempts: 3, Base: time.Millisecond}
+	client.makeRequest(WithRetryPolicy(ctx, policy), http.MethodGet, "/v2/builds", nil, nil)
+
+	logged, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %s", err)
+	}
+	// each attempt is also logged at debug level as it's sent
+	var entries []map[string]interface{}
+	for _, entry := range logged {
+		if entry["@message"] == "Retrying Buildkite API request" {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected a log entry per retry, got %d", len(entries))
+	}
+	if entries[1]["attempt"] != float64(2) || entries[1]["status_code"] != float64(503) || entries[1]["delay"] != "2ms" {
+		t.Errorf("unexpected log entry: %v", entries[1])
+	}
+}
+
+func TestRetryContextErrorLogsRetries(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	if _, err := client.RecentBuilds(ctx, "deploy", BuildFilter{}, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	logged, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %s", err)
+	}
+	var entries []map[string]interface{}
+	for _, entry := range logged {
+		if entry["@message"] == "Retrying Buildkite API request" {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) != 1 || entries[0]["status_code"] != float64(429) {
+		t.Errorf("expected the rate limited request to be logged, got %v", entries)
+	}
+}
@@ -0,0 +1,161 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// SSOSettings is the single sign-on configuration of an organization
+type SSOSettings struct {
+	// Enabled is whether members sign in through one of the organization's SSO providers
+	Enabled   bool
+	Providers []SSOProvider
+}
+
+// SSOProvider is the session configuration of an SSO provider
+type SSOProvider struct {
+	ID    string
+	UUID  string
+	Type  SSOProviderTypes
+	State SSOProviderStates
+	// SessionDurationInHours is how long a member stays signed in before having to authenticate with the provider again
+	SessionDurationInHours *int
+	// PinSessionToIpAddress ends a member's session when their IP address changes
+	PinSessionToIpAddress *bool
+}
+
+func newSSOProvider(fields SSOProviderFields) SSOProvider {
+	return SSOProvider{
+		ID:                     fields.GetId(),
+		UUID:                   fields.GetUuid(),
+		Type:                   fields.GetType(),
+		State:                  fields.GetState(),
+		SessionDurationInHours: fields.GetSessionDurationInHours(),
+		PinSessionToIpAddress:  fields.GetPinSessionToIpAddress(),
+	}
+}
+
+// GetSSOSettings returns whether SSO is enabled for the organization along with the session settings of each of its
+// providers
+func (client *Client) GetSSOSettings(ctx context.Context) (SSOSettings, error) {
+	var settings SSOSettings
+
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return settings, err
+	}
+
+	providers, err := paginateGraphQL(ctx, timeout, client.paceGraphQL, func(cursor *string) ([]SSOProvider, pageInfo, error) {
+		r, err := getOrganizationSSO(ctx, client.genqlient, client.organization, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		settings.Enabled = r.Organization.Sso.IsEnabled
+
+		var providers []SSOProvider
+		for _, edge := range r.Organization.SsoProviders.Edges {
+			providers = append(providers, newSSOProvider(edge.Node))
+		}
+
+		return providers, &r.Organization.SsoProviders.PageInfo, nil
+	})
+	if err != nil {
+		return SSOSettings{}, err
+	}
+
+	settings.Providers = providers
+	return settings, nil
+}
+
+// UpdateSSOProviderSession changes how long sessions started through an SSO provider last. Nil values are left
+// unchanged. These settings only apply to members signing in through the browser, so API tokens like the one the
+// provider uses keep working.
+func (client *Client) UpdateSSOProviderSession(ctx context.Context, id string, sessionDurationInHours *int, pinSessionToIpAddress *bool) (SSOProvider, error) {
+	if sessionDurationInHours != nil && *sessionDurationInHours < 1 {
+		return SSOProvider{}, fmt.Errorf("session duration must be at least 1 hour, got %d", *sessionDurationInHours)
+	}
+
+	timeout, err := client.operationTimeout(ctx, "update")
+	if err != nil {
+		return SSOProvider{}, err
+	}
+
+	var r *updateSSOProviderSessionResponse
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = updateSSOProviderSession(ctx, client.genqlient, id, sessionDurationInHours, pinSessionToIpAddress)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return SSOProvider{}, err
+	}
+
+	return newSSOProvider(r.SsoProviderUpdate.SsoProvider), nil
+}
+
+// GetSSOSessionDuration returns how many minutes sessions started through the organization's enabled SSO providers
+// last. Buildkite sets the duration per provider, so an error is returned if the enabled providers disagree, and
+// ErrNotFound if SSO isn't enabled or no enabled provider has a duration set.
+func (client *Client) GetSSOSessionDuration(ctx context.Context) (int, error) {
+	settings, err := client.GetSSOSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !settings.Enabled {
+		return 0, fmt.Errorf("SSO isn't enabled for %s: %w", client.organization, ErrNotFound)
+	}
+
+	hours := 0
+	for _, provider := range settings.Providers {
+		if provider.State != SSOProviderStatesEnabled || provider.SessionDurationInHours == nil {
+			continue
+		}
+		if hours != 0 && *provider.SessionDurationInHours != hours {
+			return 0, fmt.Errorf("SSO providers of %s have different session durations", client.organization)
+		}
+		hours = *provider.SessionDurationInHours
+	}
+	if hours == 0 {
+		return 0, fmt.Errorf("no SSO provider of %s has a session duration: %w", client.organization, ErrNotFound)
+	}
+
+	return hours * 60, nil
+}
+
+// SetSSOSessionDuration sets how many minutes sessions started through each of the organization's enabled SSO
+// providers last. Buildkite stores the duration in hours, so minutes must be a whole number of hours. SSO must be
+// enabled, as the duration only applies to members signing in through a provider.
+func (client *Client) SetSSOSessionDuration(ctx context.Context, minutes int) error {
+	if minutes < 60 || minutes%60 != 0 {
+		return fmt.Errorf("session duration must be a whole number of hours, got %d minutes", minutes)
+	}
+
+	settings, err := client.GetSSOSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		return errors.New("SSO must be enabled to set a session duration")
+	}
+
+	hours := minutes / 60
+	updated := false
+	for _, provider := range settings.Providers {
+		if provider.State != SSOProviderStatesEnabled {
+			continue
+		}
+		if _, err := client.UpdateSSOProviderSession(ctx, provider.ID, &hours, nil); err != nil {
+			return fmt.Errorf("unable to update SSO provider %s: %w", provider.UUID, err)
+		}
+		updated = true
+	}
+	if !updated {
+		return errors.New("SSO is enabled but there are no enabled SSO providers to set a session duration on")
+	}
+
+	return nil
+}
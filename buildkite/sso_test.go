@@ -0,0 +1,130 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGetSSOSettings(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGraphqlClient(t, func(operation string) string {
+		return `{"data": {"organization": {
+			"sso": {"isEnabled": true},
+			"ssoProviders": {
+				"pageInfo": {"endCursor": "a", "hasNextPage": false},
+				"edges": [{"node": {"__typename": "SSOProviderSAML", "id": "U1NP", "uuid": "1", "type": "SAML", "state": "ENABLED", "sessionDurationInHours": 12, "pinSessionToIpAddress": true}}]
+			}
+		}}}`
+	})
+
+	settings, err := client.GetSSOSettings(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !settings.Enabled || len(settings.Providers) != 1 {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	provider := settings.Providers[0]
+	if provider.Type != SSOProviderTypesSaml || *provider.SessionDurationInHours != 12 || !*provider.PinSessionToIpAddress {
+		t.Errorf("unexpected provider: %+v", provider)
+	}
+}
+
+func TestUpdateSSOProviderSessionValidatesDuration(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGraphqlClient(t, func(operation string) string {
+		t.Error("expected no request to be made")
+		return ""
+	})
+
+	duration := 0
+	if _, err := client.UpdateSSOProviderSession(context.Background(), "U1NP", &duration, nil); err == nil {
+		t.Error("expected an error for a zero hour session")
+	}
+}
+
+func TestSSOSessionDuration(t *testing.T) {
+	t.Parallel()
+
+	sso := func(enabled bool) string {
+		return fmt.Sprintf(`{"data": {"organization": {
+			"sso": {"isEnabled": %t},
+			"ssoProviders": {
+				"pageInfo": {"endCursor": "a", "hasNextPage": false},
+				"edges": [
+					{"node": {"__typename": "SSOProviderSAML", "id": "U1NP", "uuid": "1", "type": "SAML", "state": "ENABLED", "sessionDurationInHours": 12}},
+					{"node": {"__typename": "SSOProviderGoogleGSuite", "id": "U1NQ", "uuid": "2", "type": "GOOGLE_GSUITE", "state": "DISABLED", "sessionDurationInHours": 1}}
+				]
+			}
+		}}}`, enabled)
+	}
+
+	t.Run("reads the duration of enabled providers", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string { return sso(true) })
+
+		minutes, err := client.GetSSOSessionDuration(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if minutes != 720 {
+			t.Errorf("expected 720 minutes, got %d", minutes)
+		}
+	})
+
+	t.Run("sets the duration on enabled providers", func(t *testing.T) {
+		t.Parallel()
+
+		var updated int
+		client := newTestGraphqlClient(t, func(operation string) string {
+			if operation == "updateSSOProviderSession" {
+				updated++
+				return `{"data": {"ssoProviderUpdate": {"ssoProvider": {"__typename": "SSOProviderSAML", "id": "U1NP", "sessionDurationInHours": 2}}}}`
+			}
+			return sso(true)
+		})
+
+		if err := client.SetSSOSessionDuration(context.Background(), 120); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if updated != 1 {
+			t.Errorf("expected only the enabled provider to be updated, got %d updates", updated)
+		}
+	})
+
+	t.Run("requires SSO to be enabled", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string {
+			if operation == "updateSSOProviderSession" {
+				t.Error("expected no provider to be updated")
+			}
+			return sso(false)
+		})
+
+		if err := client.SetSSOSessionDuration(context.Background(), 120); err == nil {
+			t.Error("expected an error when SSO isn't enabled")
+		}
+		if _, err := client.GetSSOSessionDuration(context.Background()); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("requires whole hours", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestGraphqlClient(t, func(operation string) string {
+			t.Error("expected no request to be made")
+			return ""
+		})
+
+		if err := client.SetSSOSessionDuration(context.Background(), 90); err == nil {
+			t.Error("expected an error for a duration that isn't a whole number of hours")
+		}
+	})
+}
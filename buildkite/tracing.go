@@ -0,0 +1,65 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+
+	genqlient "github.com/Khan/genqlient/graphql"
+)
+
+// Tracer starts a span for each API request the provider makes, so the requests can be included in a distributed
+// trace, e.g. by adapting an OpenTelemetry tracer, without the provider depending on a tracing library. The returned
+// context is the one the request is made with.
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, attributes map[string]string) (context.Context, EndSpanFunc)
+}
+
+// EndSpanFunc ends a span started by a Tracer. status is the HTTP status of the response, or zero if no response was
+// received, and err is the error the request failed with, if any.
+type EndSpanFunc func(status int, err error)
+
+// TracerFunc adapts a function to a Tracer
+type TracerFunc func(ctx context.Context, name string, attributes map[string]string) (context.Context, EndSpanFunc)
+
+func (f TracerFunc) StartSpan(ctx context.Context, name string, attributes map[string]string) (context.Context, EndSpanFunc) {
+	return f(ctx, name, attributes)
+}
+
+// startSpan starts a span with tracer, adding the organization to its attributes. A nil tracer doesn't trace anything.
+func startSpan(ctx context.Context, tracer Tracer, name, organization string, attributes map[string]string) (context.Context, EndSpanFunc) {
+	if tracer == nil {
+		return ctx, func(int, error) {}
+	}
+
+	attributes["buildkite.organization"] = organization
+	return tracer.StartSpan(ctx, name, attributes)
+}
+
+// tracingClient starts a span for each GraphQL request
+type tracingClient struct {
+	next         genqlient.Client
+	tracer       Tracer
+	organization string
+}
+
+func newTracingClient(next genqlient.Client, tracer Tracer, organization string) *tracingClient {
+	return &tracingClient{next: next, tracer: tracer, organization: organization}
+}
+
+func (c *tracingClient) MakeRequest(ctx context.Context, req *genqlient.Request, resp *genqlient.Response) error {
+	ctx, end := startSpan(ctx, c.tracer, "buildkite.graphql "+req.OpName, c.organization, map[string]string{
+		"http.request.method":    http.MethodPost,
+		"graphql.operation.name": req.OpName,
+	})
+
+	err := c.next.MakeRequest(ctx, req, resp)
+
+	// genqlient doesn't expose the status of failed requests, but a response with GraphQL errors was still a 200
+	status := http.StatusOK
+	if err != nil && len(resp.Errors) == 0 {
+		status = 0
+	}
+	end(status, err)
+
+	return err
+}
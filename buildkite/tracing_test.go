@@ -0,0 +1,84 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// recordingTracer records each span once it ends
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []recordedSpan
+}
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]string
+	status     int
+	err        error
+}
+
+func (r *recordingTracer) StartSpan(ctx context.Context, name string, attributes map[string]string) (context.Context, EndSpanFunc) {
+	return ctx, func(status int, err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.spans = append(r.spans, recordedSpan{name: name, attributes: attributes, status: status, err: err})
+	}
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("REST requests", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := &recordingTracer{}
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		client.tracer = tracer
+
+		err := client.makeRequest(context.Background(), http.MethodGet, "/v2/organizations/test-org/pipelines/deploy?access_token=secret", nil, nil)
+		if !isStatusCode(err, http.StatusNotFound) {
+			t.Fatalf("expected a 404, got %v", err)
+		}
+
+		if len(tracer.spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+		}
+		span := tracer.spans[0]
+		if span.name != "buildkite.rest GET" || span.status != http.StatusNotFound || span.err == nil {
+			t.Errorf("unexpected span: %+v", span)
+		}
+		if span.attributes["url.path"] != "/v2/organizations/test-org/pipelines/deploy" || span.attributes["buildkite.organization"] != "test-org" {
+			t.Errorf("unexpected attributes: %v", span.attributes)
+		}
+	})
+
+	t.Run("GraphQL requests", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := &recordingTracer{}
+		client := newTestGraphqlClient(t, func(operation string) string {
+			return `{"data": {"organization": {"teams": {"pageInfo": {"hasNextPage": false}, "edges": []}}}}`
+		})
+		client.genqlient = newTracingClient(client.genqlient, tracer, "test-org")
+
+		if _, err := client.ListTeams(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(tracer.spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+		}
+		span := tracer.spans[0]
+		if span.name != "buildkite.graphql listTeams" || span.status != http.StatusOK || span.err != nil {
+			t.Errorf("unexpected span: %+v", span)
+		}
+		if span.attributes["graphql.operation.name"] != "listTeams" || span.attributes["buildkite.organization"] != "test-org" {
+			t.Errorf("unexpected attributes: %v", span.attributes)
+		}
+	})
+}
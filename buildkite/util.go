@@ -2,11 +2,22 @@ package buildkite
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/shurcooL/graphql"
 )
@@ -34,6 +45,28 @@ func GetOrganizationID(slug string, client *graphql.Client) (string, error) {
 	return id, nil
 }
 
+// describeConnectionError explains why the first request to the GraphQL API failed, if the cause is one of the usual
+// misconfigurations, so the error says what to fix rather than just what went wrong
+func describeConnectionError(endpoint string, err error) error {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var recordErr tls.RecordHeaderError
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("unable to resolve %s, check %s is correct and that DNS works from this machine: %w", dnsErr.Name, SchemaKeyGraphqlURL, err)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return fmt.Errorf("connection to %s was refused, check %s is correct and that no firewall or proxy is blocking it: %w", endpoint, SchemaKeyGraphqlURL, err)
+	case errors.As(err, &certErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &recordErr):
+		return fmt.Errorf("TLS connection to %s failed, check %s uses https and that any proxy intercepting TLS is trusted by this machine: %w", endpoint, SchemaKeyGraphqlURL, err)
+	case strings.Contains(err.Error(), "status code: 401"), strings.Contains(err.Error(), "status code: 403"):
+		return fmt.Errorf("authentication with %s failed, check %s is a valid token with GraphQL API access: %w", endpoint, SchemaKeyAPIToken, err)
+	}
+	return err
+}
+
 // GetTeamID retrieves the Buildkite team ID associated with the supplied team slug
 func GetTeamID(slug string, client *Client) (string, error) {
 	// Make sure the slug is prefixed with the organization
@@ -56,6 +89,13 @@ func GetTeamID(slug string, client *Client) (string, error) {
 	return id, nil
 }
 
+// isGraphqlID reports whether id looks like the GraphQL ID of a node of the given type. These are the base64 encoding
+// of the type and its UUID, e.g. "Team---7b8d...".
+func isGraphqlID(id, typename string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(id)
+	return err == nil && strings.HasPrefix(string(decoded), typename+"---")
+}
+
 func getenv(key string) string {
 	val, ok := os.LookupEnv(key)
 	if !ok {
@@ -64,12 +104,185 @@ func getenv(key string) string {
 	return val
 }
 
-func retryContextError(err error) *retry.RetryError {
+// retryContextError classifies err for retry.RetryContext, logging errors that are about to be retried so retries can
+// be told apart from slow responses. RetryContext doesn't expose its attempt count or delay, so unlike logRetry those
+// aren't included.
+func retryContextError(ctx context.Context, err error) *retry.RetryError {
 	if err != nil {
 		if isRetryableError(err) {
+			fields := map[string]interface{}{"error": err.Error()}
+			if code, ok := errorStatusCode(err); ok {
+				fields["status_code"] = code
+			}
+			tflog.Warn(ctx, "Retrying Buildkite API request", fields)
 			return retry.RetryableError(err)
 		}
 		return retry.NonRetryableError(err)
 	}
 	return nil
 }
+
+// pageInfo is the pagination state returned with each page of a GraphQL connection
+type pageInfo interface {
+	GetEndCursor() string
+	GetHasNextPage() bool
+}
+
+// paginateGraphQL calls fetch with the cursor for each successive page of a GraphQL connection, collecting the items
+// from every page. Each page is retried on its own so a transient failure doesn't restart the whole listing. When pace
+// isn't nil it's called before fetching each page after the first, so it can slow down a large listing.
+func paginateGraphQL[T any](ctx context.Context, timeout time.Duration, pace func(context.Context) error, fetch func(cursor *string) ([]T, pageInfo, error)) ([]T, error) {
+	var items []T
+	var cursor *string
+
+	for {
+		var page []T
+		var info pageInfo
+		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+			var err error
+			page, info, err = fetch(cursor)
+			return retryContextError(ctx, err)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if info == nil || !info.GetHasNextPage() {
+			return items, nil
+		}
+		endCursor := info.GetEndCursor()
+		cursor = &endCursor
+
+		if pace != nil {
+			if err := pace(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// defaultPerPage is the page size requested from REST list endpoints. It's the maximum Buildkite allows, to keep the
+// number of requests down
+const defaultPerPage = 100
+
+// paginateREST calls fetch with the page and per_page query parameters for each successive page of a REST list
+// endpoint, collecting the items from every page until the Link header no longer has a next page. A perPage of zero
+// uses defaultPerPage. Like paginateGraphQL, each page is retried on its own.
+func paginateREST[T any](ctx context.Context, timeout time.Duration, perPage int, fetch func(pagination url.Values) ([]T, http.Header, error)) ([]T, error) {
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	if perPage < 1 || perPage > 100 {
+		return nil, fmt.Errorf("page size must be between 1 and 100, got %d", perPage)
+	}
+
+	var items []T
+
+	for page := 1; ; page++ {
+		pagination := url.Values{}
+		pagination.Set("page", fmt.Sprint(page))
+		pagination.Set("per_page", fmt.Sprint(perPage))
+
+		var pageItems []T
+		var header http.Header
+		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+			var err error
+			pageItems, header, err = fetch(pagination)
+			return retryContextError(ctx, err)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, pageItems...)
+
+		if !hasNextPage(header) {
+			return items, nil
+		}
+	}
+}
+
+// errStopPages is returned by a streamPages handler to stop reading any more items without failing
+var errStopPages = errors.New("stop reading pages")
+
+// streamPages calls handler with each item of a REST list endpoint in turn, requesting the next page only once every
+// item of the previous one has been handled. Unlike paginateREST only a single page is held in memory, for listings
+// too large to collect, like every build of an organization. path may have its own query, and defaultPerPage is used
+// unless it sets per_page. Each page is retried on its own, but handler is never called twice with the same item.
+func (client *Client) streamPages(ctx context.Context, path string, handler func(item json.RawMessage) error) error {
+	timeout, err := client.operationTimeout(ctx, "read")
+	if err != nil {
+		return err
+	}
+
+	path, rawQuery, _ := strings.Cut(path, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("invalid query for %s: %w", path, err)
+	}
+	if query.Get("per_page") == "" {
+		query.Set("per_page", fmt.Sprint(defaultPerPage))
+	}
+
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprint(page))
+
+		var items []json.RawMessage
+		var header http.Header
+		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+			items = nil
+			var err error
+			header, err = client.doRequest(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &items)
+			return retryContextError(ctx, err)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := handler(item); err != nil {
+				if errors.Is(err, errStopPages) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if !hasNextPage(header) {
+			return nil
+		}
+	}
+}
+
+// hasNextPage reports whether a REST response's Link header points to another page
+func hasNextPage(header http.Header) bool {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		if strings.Contains(link, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// getNodeUUID looks up the UUID of a cluster or team from its GraphQL ID, for REST endpoints that are keyed by UUID
+func (client *Client) getNodeUUID(ctx context.Context, timeout time.Duration, id string) (string, error) {
+	var r *getNodeResponse
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		r, err = getNode(ctx, client.genqlient, id)
+		return retryContextError(ctx, err)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch node := r.GetNode().(type) {
+	case *getNodeNodeCluster:
+		return node.Uuid, nil
+	case *getNodeNodeTeam:
+		return node.Uuid, nil
+	}
+	return "", fmt.Errorf("%s: %w", id, ErrNotFound)
+}
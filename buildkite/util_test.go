@@ -1,7 +1,18 @@
 package buildkite
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"syscall"
 	"testing"
 )
 
@@ -25,3 +36,133 @@ func TestGetOrganizationIDMissing(t *testing.T) {
 		t.Fatalf("Nonexistent organization found")
 	}
 }
+
+func TestDescribeConnectionError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"dns failure": {
+			err:      &url.Error{Op: "Post", URL: defaultGraphqlEndpoint, Err: &net.OpError{Op: "dial", Err: &net.DNSError{Name: "graphql.buildkite.com", Err: "no such host", IsNotFound: true}}},
+			expected: "unable to resolve graphql.buildkite.com",
+		},
+		"connection refused": {
+			err:      &url.Error{Op: "Post", URL: defaultGraphqlEndpoint, Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}},
+			expected: "was refused",
+		},
+		"untrusted certificate": {
+			err:      &url.Error{Op: "Post", URL: defaultGraphqlEndpoint, Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}},
+			expected: "TLS connection",
+		},
+		"bad token": {
+			err:      errors.New("non-200 OK status code: 401 Unauthorized body: \"\""),
+			expected: "check api_token",
+		},
+		"anything else": {
+			err:      errors.New("organization test not found"),
+			expected: "organization test not found",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := describeConnectionError(defaultGraphqlEndpoint, tc.err)
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected error to contain %q, got %q", tc.expected, err)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected the original error to be wrapped")
+			}
+		})
+	}
+}
+
+func TestIsGraphqlID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]bool{
+		"VGVhbS0tLTRiM2E=": true,
+		"UGlwZWxpbmUtLS00MzVjYWQ1OC1lODFkLTQ1YWYtODYzNy1iMWNmODA3MDIzOGQ=": false,
+		"platform": false,
+	}
+
+	for id, expected := range testCases {
+		if got := isGraphqlID(id, "Team"); got != expected {
+			t.Errorf("expected isGraphqlID(%q) to be %v, got %v", id, expected, got)
+		}
+	}
+}
+
+func TestStreamPages(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("state"); got != "passed" {
+			t.Errorf("expected the path's query to be kept, got state=%s", got)
+		}
+		if got := query.Get("per_page"); got != "100" {
+			t.Errorf("expected the maximum page size by default, got %s", got)
+		}
+
+		if query.Get("page") == "1" {
+			w.Header().Set("Link", `<https://api.buildkite.com/v2/builds?page=2>; rel="next"`)
+			w.Write([]byte(`[{"number": 3}, {"number": 2}]`))
+			return
+		}
+		w.Write([]byte(`[{"number": 1}]`))
+	})
+	path := "/v2/organizations/test-org/builds?state=passed"
+
+	t.Run("handles every item across pages", func(t *testing.T) {
+		t.Parallel()
+
+		var numbers []int
+		err := client.streamPages(context.Background(), path, func(item json.RawMessage) error {
+			var build Build
+			if err := json.Unmarshal(item, &build); err != nil {
+				return err
+			}
+			numbers = append(numbers, build.Number)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if fmt.Sprint(numbers) != "[3 2 1]" {
+			t.Errorf("expected the items of both pages in order, got %v", numbers)
+		}
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		t.Parallel()
+
+		handled := 0
+		err := client.streamPages(context.Background(), path, func(item json.RawMessage) error {
+			handled++
+			return errStopPages
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if handled != 1 {
+			t.Errorf("expected a single item to be handled, got %d", handled)
+		}
+	})
+
+	t.Run("returns handler errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := client.streamPages(context.Background(), path, func(item json.RawMessage) error {
+			return errors.New("report failed")
+		})
+		if err == nil || err.Error() != "report failed" {
+			t.Errorf("expected the handler's error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,7 @@
+data "buildkite_agent_jobs" "agent" {
+  agent_id = "QWdlbnQtLS0wMThiMjM0NS02Nzg5"
+}
+
+output "running_jobs" {
+  value = [for job in data.buildkite_agent_jobs.agent.jobs : job.pipeline_slug if job.state == "RUNNING"]
+}
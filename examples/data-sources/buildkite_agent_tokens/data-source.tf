@@ -0,0 +1,5 @@
+data "buildkite_agent_tokens" "all" {}
+
+output "active_tokens" {
+  value = [for token in data.buildkite_agent_tokens.all.tokens : token.description if token.revoked_at == null]
+}
@@ -0,0 +1,8 @@
+data "buildkite_build_env" "release" {
+  pipeline_slug = "monolith"
+  number        = 42
+}
+
+output "release_version" {
+  value = data.buildkite_build_env.release.env["RELEASE_VERSION"]
+}
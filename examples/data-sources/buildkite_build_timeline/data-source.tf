@@ -0,0 +1,8 @@
+data "buildkite_build_timeline" "release" {
+  pipeline_slug = "monolith"
+  number        = 42
+}
+
+output "job_starts" {
+  value = { for event in data.buildkite_build_timeline.release.events : event.job_name => event.time if event.event == "started" && event.job_id != null }
+}
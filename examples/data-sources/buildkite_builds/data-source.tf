@@ -0,0 +1,10 @@
+data "buildkite_builds" "main" {
+  pipeline_slug = "monolith"
+  branch        = ["main"]
+  state         = ["passed"]
+  created_from  = "2023-10-01T00:00:00Z"
+}
+
+output "latest_passing_commit" {
+  value = data.buildkite_builds.main.builds[0].commit
+}
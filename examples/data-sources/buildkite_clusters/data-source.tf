@@ -0,0 +1,5 @@
+data "buildkite_clusters" "all" {}
+
+output "clusters_without_default_queue" {
+  value = [for cluster in data.buildkite_clusters.all.clusters : cluster.name if cluster.default_queue_id == null]
+}
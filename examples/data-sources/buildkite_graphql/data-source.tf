@@ -0,0 +1,17 @@
+data "buildkite_graphql" "pipeline" {
+  query = <<-EOT
+    query getPipeline($slug: ID!) {
+      pipeline(slug: $slug) {
+        defaultTimeoutInMinutes
+      }
+    }
+  EOT
+
+  variables = jsonencode({
+    slug = "my-org/monolith"
+  })
+}
+
+output "default_timeout" {
+  value = jsondecode(data.buildkite_graphql.pipeline.result).pipeline.defaultTimeoutInMinutes
+}
@@ -0,0 +1,10 @@
+data "buildkite_organization_features" "features" {}
+
+resource "buildkite_team" "platform" {
+  count = data.buildkite_organization_features.features.teams_enabled ? 1 : 0
+
+  name                = "Platform"
+  privacy             = "VISIBLE"
+  default_team        = false
+  default_member_role = "MEMBER"
+}
@@ -0,0 +1,7 @@
+data "buildkite_organization_usage" "may" {
+  period = "2024-05"
+}
+
+output "job_minutes" {
+  value = data.buildkite_organization_usage.may.job_minutes
+}
@@ -0,0 +1,8 @@
+data "buildkite_pipeline_badge" "monolith" {
+  slug = "monolith"
+}
+
+output "readme_badge" {
+  value     = data.buildkite_pipeline_badge.monolith.markdown
+  sensitive = true
+}
@@ -0,0 +1,9 @@
+data "buildkite_pipeline_export" "monolith" {
+  slug = "monolith"
+}
+
+# keep a copy of the steps alongside the rest of the configuration
+resource "local_file" "monolith_steps" {
+  filename = "${path.module}/pipelines/monolith.yml"
+  content  = data.buildkite_pipeline_export.monolith.steps
+}
@@ -0,0 +1,7 @@
+data "buildkite_pipeline_integration" "deploy" {
+  slug = "deploy"
+}
+
+output "last_webhook_build_at" {
+  value = data.buildkite_pipeline_integration.deploy.last_webhook_build_at
+}
@@ -0,0 +1,9 @@
+data "buildkite_queue_metrics" "default" {
+  agent_token = var.agent_token
+  queue       = "default"
+}
+
+# how many agents are needed to run every job waiting on the queue
+output "desired_agents" {
+  value = data.buildkite_queue_metrics.default.scheduled_jobs + data.buildkite_queue_metrics.default.running_jobs
+}
@@ -0,0 +1,5 @@
+data "buildkite_rate_limit" "current" {}
+
+output "requests_remaining" {
+  value = data.buildkite_rate_limit.current.remaining
+}
@@ -0,0 +1,7 @@
+data "buildkite_team_members" "platform" {
+  team_id = buildkite_team.platform.id
+}
+
+output "maintainers" {
+  value = [for member in data.buildkite_team_members.platform.members : member.user_email if member.role == "MAINTAINER"]
+}
@@ -0,0 +1,5 @@
+data "buildkite_teams" "all" {}
+
+output "empty_teams" {
+  value = [for team in data.buildkite_teams.all.teams : team.slug if team.member_count == 0]
+}
@@ -0,0 +1,12 @@
+# create a cluster
+resource "buildkite_cluster" "primary" {
+  name = "Primary cluster"
+}
+
+# store a secret that agents in the cluster can read with `buildkite-agent secret get DEPLOY_KEY`
+resource "buildkite_cluster_secret" "deploy_key" {
+  cluster_id  = buildkite_cluster.primary.id
+  key         = "DEPLOY_KEY"
+  value       = var.deploy_key
+  description = "SSH key used to deploy the monolith"
+}
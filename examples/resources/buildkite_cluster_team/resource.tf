@@ -0,0 +1,16 @@
+resource "buildkite_cluster" "primary" {
+  name = "Primary cluster"
+}
+
+resource "buildkite_team" "platform" {
+  name                = "Platform"
+  privacy             = "VISIBLE"
+  default_team        = false
+  default_member_role = "MEMBER"
+}
+
+# allow the platform team to manage the primary cluster
+resource "buildkite_cluster_team" "platform" {
+  cluster_id = buildkite_cluster.primary.id
+  team_id    = buildkite_team.platform.id
+}
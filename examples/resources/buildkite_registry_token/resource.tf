@@ -0,0 +1,5 @@
+# create a token CI can use to publish to the "gems" registry
+resource "buildkite_registry_token" "ci" {
+  registry_slug = "gems"
+  description   = "Publishing from CI"
+}
@@ -13,4 +13,7 @@
 #     }
 #   }
 # }
-terraform import buildkite_team.everyone UGlwZWxpbmUtLS00MzVjYWQ1OC1lODFkLTQ1YWYtODYzNy1iMWNmODA3MDIzOGQ=
+terraform import buildkite_team.everyone VGVhbS0tLTQzNWNhZDU4LWU4MWQtNDVhZi04NjM3LWIxY2Y4MDcwMjM4ZA==
+
+# or using the team's slug
+terraform import buildkite_team.everyone everyone